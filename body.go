@@ -0,0 +1,273 @@
+package paramex
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DecoderFunc decodes a request body into v, which is a reference of the body field.
+// Decoders return io.EOF for empty bodies, same as json.Decoder
+type DecoderFunc func(body io.Reader, v interface{}) error
+
+// bodyDecoder decodes the request body into value, a reference of the body field of prm
+type bodyDecoder func(p extractor, req *http.Request, body io.Reader, prm param, value reflect.Value) error
+
+// defaultDecoders are the decoders of media types, which are negotiated by the request Content-Type
+var defaultDecoders = map[string]bodyDecoder{
+	`application/json`:                  decodeJSON,
+	`application/xml`:                   decodeXML,
+	`text/xml`:                          decodeXML,
+	`application/x-www-form-urlencoded`: decodeForm,
+	`multipart/form-data`:               decodeForm,
+	`text/plain`:                        decodeText,
+	`text/csv`:                          decodeCSV,
+}
+
+// bodyField returns the field bound to the request body, preferring a field having the body tag option
+// over a field named Body
+func (p extractor) bodyField(t reflect.Type) (param, bool) {
+	var prm param
+	found := false
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		raw, tagged := field.Tag.Lookup(p.opts.tagName)
+		tag := parseTag(raw)
+		if field.PkgPath != `` || tagged && tag.name == `-` || !tag.body(field) {
+			continue
+		}
+		if _, ok := tag.option(`body`); ok || !found {
+			prm = param{field: field, key: string(InBody), keys: []string{string(InBody)}, in: InBody, tag: tag}
+			found = true
+		}
+	}
+	return prm, found
+}
+
+// bindBody decodes the request body into the body field of v by decode, or else by the decoder of the
+// request Content-Type. Structs without a body field are not bound
+func (p extractor) bindBody(v interface{}, req *http.Request, decode bodyDecoder) (*Result, error) {
+	t := reflect.TypeOf(v)
+	if v == nil || t.Kind() != reflect.Ptr {
+		return nil, ErrorNotAssignable{
+			fmt.Errorf(`type of %v is not assignabale, required object reference`, t)}
+	}
+	elem := reflect.ValueOf(v).Elem()
+	if elem.Kind() != reflect.Struct {
+		return nil, ErrorUnSupportedType{
+			fmt.Errorf(`type of %v is not extractable, required struct object`, elem.Type().String())}
+	}
+
+	prm, ok := p.bodyField(elem.Type())
+	if !ok {
+		return &Result{}, nil
+	}
+	if req.Body == nil || req.Body == http.NoBody {
+		return &Result{}, p.absentBody(prm)
+	}
+	if decode == nil {
+		var err error
+		decode, err = p.decoder(req)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	value := reflect.New(prm.field.Type)
+	err := decode(p, req, http.MaxBytesReader(nil, req.Body, p.opts.maxBodySize), prm, value)
+	if errors.Is(err, io.EOF) {
+		return &Result{}, p.absentBody(prm)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	elem.FieldByIndex(prm.field.Index).Set(value.Elem())
+	return &Result{Params: []BoundParam{{Field: prm.field.Name, Key: prm.key, In: InBody}}}, nil
+}
+
+// decoder returns the decoder of the request Content-Type, preferring decoders registered with WithDecoder.
+// Media types having the +json or +xml suffix, e.g. application/problem+json, are decoded as JSON or XML
+func (p extractor) decoder(req *http.Request) (bodyDecoder, error) {
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get(`Content-Type`))
+	if err != nil {
+		return nil, ErrorUnsupportedMediaType{fmt.Errorf(`invalid content type "%v" due to %v`,
+			req.Header.Get(`Content-Type`), err)}
+	}
+
+	if fn, ok := p.opts.decoders[mediaType]; ok {
+		return customDecoder(fn), nil
+	}
+	if decode, ok := defaultDecoders[mediaType]; ok {
+		return decode, nil
+	}
+	switch {
+	case strings.HasSuffix(mediaType, `+json`):
+		return decodeJSON, nil
+	case strings.HasSuffix(mediaType, `+xml`):
+		return decodeXML, nil
+	}
+
+	supported := make([]string, 0, len(defaultDecoders)+len(p.opts.decoders))
+	for mediaType := range defaultDecoders {
+		supported = append(supported, mediaType)
+	}
+	for mediaType := range p.opts.decoders {
+		if _, ok := defaultDecoders[mediaType]; !ok {
+			supported = append(supported, mediaType)
+		}
+	}
+	sort.Strings(supported)
+	return nil, ErrorUnsupportedMediaType{fmt.Errorf(`unsupported content type "%v", supported types are [%v]`,
+		mediaType, strings.Join(supported, `, `))}
+}
+
+// absentBody fails with ErrorRequiredParam when the body field is required
+func (p extractor) absentBody(prm param) error {
+	if _, required := prm.tag.option(`required`); required {
+		return ErrorRequiredParam{prm.fieldError(errors.New(`required request body is missing`))}
+	}
+	return nil
+}
+
+func decodeJSON(p extractor, _ *http.Request, body io.Reader, prm param, value reflect.Value) error {
+	decoder := json.NewDecoder(body)
+	if p.opts.disallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	if p.opts.useNumber {
+		decoder.UseNumber()
+	}
+
+	err := decoder.Decode(value.Interface())
+	if errors.Is(err, io.EOF) {
+		return err
+	}
+	if err == nil && decoder.Decode(&json.RawMessage{}) != io.EOF {
+		err = errors.New(`invalid data after the top level JSON value`)
+	}
+	if err != nil {
+		return jsonError(prm, err)
+	}
+	return nil
+}
+
+// jsonError converts errors of decoding JSON bodies into paramex errors. Errors of values name
+// the JSON path of the value, e.g. items.0.quantity
+func jsonError(prm param, err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return ErrorMalformedRequest{fmt.Errorf(`invalid JSON body at offset %d due to %v`, syntaxErr.Offset, err)}
+	case errors.As(err, &typeErr):
+		path := typeErr.Field
+		if path == `` {
+			path = prm.key
+		}
+		prm.key = path
+		return ErrorUnmarshalType{prm.fieldError(
+			fmt.Errorf(`error unmarshalling JSON %v into [%v] of "%v"`, typeErr.Value, typeErr.Type, path))}
+	case strings.HasPrefix(err.Error(), `json: unknown field `):
+		key, unquoteErr := strconv.Unquote(strings.TrimPrefix(err.Error(), `json: unknown field `))
+		if unquoteErr != nil {
+			key = strings.TrimPrefix(err.Error(), `json: unknown field `)
+		}
+		return ErrorUnknownParams{
+			error: fmt.Errorf(`unknown JSON field "%v" received`, key),
+			In:    InBody,
+			Keys:  []string{key},
+		}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return ErrorMalformedRequest{errors.New(`unexpected end of JSON body`)}
+	default:
+		return decodeError(`JSON`, err)
+	}
+}
+
+func decodeXML(_ extractor, _ *http.Request, body io.Reader, _ param, value reflect.Value) error {
+	err := xml.NewDecoder(body).Decode(value.Interface())
+	if err == nil || errors.Is(err, io.EOF) {
+		return err
+	}
+	return decodeError(`XML`, err)
+}
+
+// decodeForm binds url encoded and multipart form values into the body field, which should be
+// a Go struct annotated with param, the same as ExtractForms
+func decodeForm(p extractor, req *http.Request, _ io.Reader, _ param, value reflect.Value) error {
+	err := p.parseForm(req)
+	if err != nil {
+		return parseFormError(err)
+	}
+	if len(req.PostForm) == 0 {
+		return io.EOF
+	}
+	_, err = p.extract(value.Interface(), InForm, valuesSource(req.PostForm))
+	return err
+}
+
+// decodeText sets the plain text body into string and []byte fields, encoding.TextUnmarshaler
+// fields and fields of types having a converter
+func decodeText(p extractor, _ *http.Request, body io.Reader, prm param, value reflect.Value) error {
+	text, err := io.ReadAll(body)
+	if err != nil {
+		return decodeError(`text`, err)
+	}
+	if len(text) == 0 {
+		return io.EOF
+	}
+
+	if u, ok := value.Interface().(encoding.TextUnmarshaler); ok {
+		err = u.UnmarshalText(text)
+		if err != nil {
+			return ErrorUnmarshalType{prm.fieldError(fmt.Errorf(`error unmarshalling text body due to %v`, err))}
+		}
+		return nil
+	}
+	if value.Elem().Kind() == reflect.Slice && value.Elem().Type().Elem().Kind() == reflect.Uint8 {
+		value.Elem().SetBytes(text)
+		return nil
+	}
+	c, ok := p.opts.converter(prm.field.Type)
+	if !ok {
+		return ErrorUnSupportedParamType{prm.fieldError(
+			fmt.Errorf(`error unmarshalling text body into %v, unsupported body type`, prm.field.Type))}
+	}
+	converted, err := c.convertTo(prm, prm.field.Type, string(text))
+	if err != nil {
+		return err
+	}
+	value.Elem().Set(converted)
+	return nil
+}
+
+// customDecoder adapts a decoder registered with WithDecoder, which may return paramex errors
+func customDecoder(fn DecoderFunc) bodyDecoder {
+	return func(_ extractor, _ *http.Request, body io.Reader, _ param, value reflect.Value) error {
+		err := fn(body, value.Interface())
+		var coder StatusCoder
+		if err == nil || errors.Is(err, io.EOF) || errors.As(err, &coder) {
+			return err
+		}
+		return decodeError(`body`, err)
+	}
+}
+
+// decodeError converts errors of decoders into ErrorBodyTooLarge or ErrorMalformedRequest
+func decodeError(format string, err error) error {
+	var sizeErr *http.MaxBytesError
+	if errors.As(err, &sizeErr) {
+		return ErrorBodyTooLarge{fmt.Errorf(`request body exceeds %d bytes`, sizeErr.Limit)}
+	}
+	return ErrorMalformedRequest{fmt.Errorf(`invalid %v body due to %v`, format, err)}
+}
@@ -0,0 +1,237 @@
+package paramex
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type orderItem struct {
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+type order struct {
+	Customer string      `json:"customer"`
+	Items    []orderItem `json:"items"`
+}
+
+type createOrder struct {
+	DryRun bool   `param:"dry_run"`
+	Order  order  `param:",body,required"`
+	Trace  string `param:"X-Trace-Id,in=header"`
+}
+
+func TestExtractor_ExtractJSON(t *testing.T) {
+	extractor := NewParamExtractor()
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, `https://nipuna.lk/orders?dry_run=true`, strings.NewReader(body))
+		req.Header.Set(`Content-Type`, `application/json`)
+		return req
+	}
+
+	t.Run(`test params and body`, func(t *testing.T) {
+		req := newRequest(`{"customer": "nipuna", "items": [{"sku": "a", "quantity": 2}]}`)
+		params := createOrder{}
+		err := extractor.ExtractQueries(&params, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = extractor.ExtractJSON(&params, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := createOrder{DryRun: true, Order: order{Customer: `nipuna`, Items: []orderItem{{`a`, 2}}}}
+		if !reflect.DeepEqual(params, expected) {
+			t.Errorf(`expected %v, but received %v`, expected, params)
+		}
+	})
+
+	t.Run(`test body field`, func(t *testing.T) {
+		params := struct {
+			Body map[string]interface{}
+		}{}
+		err := NewParamExtractor(WithUseNumber()).ExtractJSON(&params, newRequest(`{"total": 10.50}`))
+		if err != nil || params.Body[`total`] != json.Number(`10.50`) {
+			t.Errorf(`unexpected body %v due to %v`, params.Body, err)
+		}
+
+		result, err := extractor.Bind(&handlerParams{}, newRequest(`{}`), InBody)
+		if err != nil || len(result.Params) != 0 {
+			t.Errorf(`expected no bound body, but received %v due to %v`, result, err)
+		}
+	})
+
+	t.Run(`test errors`, func(t *testing.T) {
+		tests := []struct {
+			body      string
+			extractor ParamExtractor
+			err       interface{}
+		}{
+			{``, extractor, ErrorRequiredParam{}},
+			{`{"customer": `, extractor, ErrorMalformedRequest{}},
+			{`{"customer": nipuna}`, extractor, ErrorMalformedRequest{}},
+			{`{} {}`, extractor, ErrorMalformedRequest{}},
+			{`{"items": [{"quantity": "two"}]}`, extractor, ErrorUnmarshalType{}},
+			{`{"total": 10}`, NewParamExtractor(WithDisallowUnknownFields()), ErrorUnknownParams{}},
+			{`{"customer": "` + strings.Repeat(`a`, 100) + `"}`, NewParamExtractor(WithMaxBodySize(64)), ErrorBodyTooLarge{}},
+		}
+		for _, test := range tests {
+			err := test.extractor.ExtractJSON(&createOrder{}, newRequest(test.body))
+			if reflect.TypeOf(err) != reflect.TypeOf(test.err) {
+				t.Errorf(`%v: expected %T, but received %v`, test.body, test.err, err)
+			}
+		}
+	})
+
+	t.Run(`test error paths`, func(t *testing.T) {
+		err := extractor.ExtractJSON(&createOrder{}, newRequest(`{"items": [{"quantity": "two"}]}`))
+		fieldErr := &FieldError{}
+		if !errors.As(err, &fieldErr) || fieldErr.Field != `Order` || !strings.HasPrefix(fieldErr.Name, `items.`) || !strings.HasSuffix(fieldErr.Name, `.quantity`) || fieldErr.In != InBody {
+			t.Errorf(`unexpected field error %+v`, fieldErr)
+		}
+
+		err = NewParamExtractor(WithDisallowUnknownFields()).ExtractJSON(&createOrder{}, newRequest(`{"total": 10}`))
+		unknownErr := ErrorUnknownParams{}
+		if !errors.As(err, &unknownErr) || !reflect.DeepEqual(unknownErr.Keys, []string{`total`}) {
+			t.Errorf(`unexpected unknown params %v`, err)
+		}
+	})
+
+	t.Run(`test handler`, func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		NewHandler(func(_ context.Context, params createOrder) (order, error) {
+			return params.Order, nil
+		}).ServeHTTP(rec, newRequest(`{"customer": "nipuna"}`))
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"customer":"nipuna"`) {
+			t.Errorf(`unexpected response %d %v`, rec.Code, rec.Body.String())
+		}
+	})
+}
+
+type payment struct {
+	XMLName  xml.Name `json:"-" xml:"payment"`
+	Amount   int      `json:"amount" xml:"amount" param:"amount"`
+	Currency string   `json:"currency" xml:"currency" param:"currency"`
+}
+
+func TestExtractor_ExtractBody(t *testing.T) {
+	extractor := NewParamExtractor(WithDecoder(`text/csv`, func(body io.Reader, v interface{}) error {
+		record, err := csv.NewReader(body).Read()
+		if err != nil {
+			return err
+		}
+		amount, err := strconv.Atoi(record[0])
+		if err != nil {
+			return ErrorUnmarshalType{err}
+		}
+		*v.(*payment) = payment{Amount: amount, Currency: record[1]}
+		return nil
+	}))
+	newRequest := func(contentType, body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, `https://nipuna.lk/payments`, strings.NewReader(body))
+		req.Header.Set(`Content-Type`, contentType)
+		return req
+	}
+
+	t.Run(`test negotiated decoders`, func(t *testing.T) {
+		expected := payment{Amount: 100, Currency: `LKR`}
+		tests := map[string]string{
+			`application/json; charset=utf-8`:   `{"amount": 100, "currency": "LKR"}`,
+			`application/vnd.payment+json`:      `{"amount": 100, "currency": "LKR"}`,
+			`application/xml`:                   `<payment><amount>100</amount><currency>LKR</currency></payment>`,
+			`text/xml`:                          `<payment><amount>100</amount><currency>LKR</currency></payment>`,
+			`application/x-www-form-urlencoded`: `amount=100&currency=LKR`,
+			`TEXT/CSV`:                          `100,LKR`,
+		}
+		for contentType, body := range tests {
+			params := struct {
+				Body payment
+			}{}
+			err := extractor.ExtractBody(&params, newRequest(contentType, body))
+			if err != nil {
+				t.Errorf(`%v: unexpected error %v`, contentType, err)
+			}
+			params.Body.XMLName = xml.Name{}
+			if params.Body != expected {
+				t.Errorf(`%v: expected %v, but received %v`, contentType, expected, params.Body)
+			}
+		}
+	})
+
+	t.Run(`test multipart form`, func(t *testing.T) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		_ = writer.WriteField(`amount`, `100`)
+		_ = writer.WriteField(`currency`, `LKR`)
+		_ = writer.Close()
+
+		params := struct {
+			Payment payment `param:",body"`
+		}{}
+		err := extractor.ExtractBody(&params, newRequest(writer.FormDataContentType(), body.String()))
+		if err != nil || params.Payment.Amount != 100 || params.Payment.Currency != `LKR` {
+			t.Errorf(`unexpected payment %v due to %v`, params.Payment, err)
+		}
+	})
+
+	t.Run(`test plain text`, func(t *testing.T) {
+		text := struct {
+			Body string
+		}{}
+		err := extractor.ExtractBody(&text, newRequest(`text/plain`, `hello`))
+		if err != nil || text.Body != `hello` {
+			t.Errorf(`unexpected text %v due to %v`, text.Body, err)
+		}
+
+		number := struct {
+			Count int `param:",body"`
+		}{}
+		err = extractor.ExtractBody(&number, newRequest(`text/plain`, `42`))
+		if err != nil || number.Count != 42 {
+			t.Errorf(`unexpected count %v due to %v`, number.Count, err)
+		}
+		err = extractor.ExtractBody(&number, newRequest(`text/plain`, `forty two`))
+		if _, ok := err.(ErrorUnmarshalType); !ok {
+			t.Errorf(`expected "ErrorUnmarshalType", but received %v`, err)
+		}
+	})
+
+	t.Run(`test errors`, func(t *testing.T) {
+		tests := []struct {
+			contentType string
+			body        string
+			err         interface{}
+		}{
+			{`application/yaml`, `amount: 100`, ErrorUnsupportedMediaType{}},
+			{``, `{"amount": 100}`, ErrorUnsupportedMediaType{}},
+			{`application/json; charset`, `{"amount": 100}`, ErrorUnsupportedMediaType{}},
+			{`application/xml`, `<payment><amount>one</amount></payment>`, ErrorMalformedRequest{}},
+			{`text/csv`, `one,LKR`, ErrorUnmarshalType{}},
+			{`text/csv`, `"100,LKR`, ErrorMalformedRequest{}},
+		}
+		for _, test := range tests {
+			params := struct {
+				Body payment
+			}{}
+			err := extractor.ExtractBody(&params, newRequest(test.contentType, test.body))
+			if reflect.TypeOf(err) != reflect.TypeOf(test.err) {
+				t.Errorf(`%v: expected %T, but received %v`, test.contentType, test.err, err)
+			}
+			if _, ok := test.err.(ErrorUnsupportedMediaType); ok && statusCode(err) != http.StatusUnsupportedMediaType {
+				t.Errorf(`%v: expected status 415, but received %d`, test.contentType, statusCode(err))
+			}
+		}
+	})
+}
@@ -0,0 +1,284 @@
+package paramex
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// bracketNode is a node of the tree of bracket notation keys, e.g. filter[owner][id]
+type bracketNode struct {
+	// key is the key of the node, e.g. filter[owner]
+	key      string
+	values   []string
+	children map[string]*bracketNode
+}
+
+func (n *bracketNode) child(segment string) *bracketNode {
+	c, ok := n.children[segment]
+	if !ok {
+		if n.children == nil {
+			n.children = map[string]*bracketNode{}
+		}
+		c = &bracketNode{key: n.key + `[` + segment + `]`}
+		n.children[segment] = c
+	}
+	return c
+}
+
+// sortedChildren returns segments of children in sorted order
+func (n *bracketNode) sortedChildren() []string {
+	segments := make([]string, 0, len(n.children))
+	for segment := range n.children {
+		segments = append(segments, segment)
+	}
+	sort.Strings(segments)
+	return segments
+}
+
+// parseBracketKey splits a bracket notation key into the base key and the bracket segments,
+// e.g. filter[owner][id] into filter and [owner id]. Keys without brackets or with
+// malformed brackets are not bracket notation keys
+func parseBracketKey(key string) (string, []string, bool) {
+	i := strings.IndexByte(key, '[')
+	if i <= 0 {
+		return ``, nil, false
+	}
+
+	base, rest := key[:i], key[i:]
+	var segments []string
+	for rest != `` {
+		end := strings.IndexByte(rest, ']')
+		if rest[0] != '[' || end < 0 {
+			return ``, nil, false
+		}
+		segments = append(segments, rest[1:end])
+		rest = rest[end+1:]
+	}
+	return base, segments, true
+}
+
+// nested reports whether fields of type t are bound from bracket notation keys, which
+// are structs, maps and slices of them not having a converter
+func (p extractor) nested(t reflect.Type) bool {
+	if _, ok := p.opts.converter(t); ok {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map:
+		return true
+	case reflect.Slice:
+		return p.nested(t.Elem())
+	default:
+		return false
+	}
+}
+
+// bindBrackets binds bracket notation keys of prm into nested structs, maps and slices,
+// e.g. filter[owner][id]=5 and items[0][sku]=a
+func (p extractor) bindBrackets(value reflect.Value, prm param, src Source) (*BoundParam, error) {
+	for _, key := range prm.keys {
+		prm.key = key
+		root, err := p.bracketTree(prm, src)
+		if err != nil {
+			return nil, err
+		}
+		if root == nil {
+			continue
+		}
+
+		prm.path = prm.field.Name
+		err = p.bindNode(value, prm.field.Type, root, prm)
+		if err != nil {
+			return nil, err
+		}
+		return &BoundParam{Field: prm.field.Name, Key: key, In: prm.in, Alias: key != prm.keys[0]}, nil
+	}
+	return nil, nil
+}
+
+// bracketTree returns the tree of bracket notation keys of prm, or nil when src has no such keys
+func (p extractor) bracketTree(prm param, src Source) (*bracketNode, error) {
+	keys := src.Keys()
+	sort.Strings(keys)
+
+	var root *bracketNode
+	for _, key := range keys {
+		base, segments, ok := parseBracketKey(key)
+		if !ok || !p.sameKey(base, prm.key) {
+			continue
+		}
+		if len(segments) > p.opts.maxDepth {
+			prm.key = key
+			return nil, ErrorLimitExceeded{prm.fieldError(
+				fmt.Errorf(`depth of key exceeds the limit of %d`, p.opts.maxDepth))}
+		}
+
+		if root == nil {
+			root = &bracketNode{key: prm.key}
+		}
+		node := root
+		for _, segment := range segments {
+			node = node.child(segment)
+		}
+		values, _ := src.Lookup(key)
+		node.values = append(node.values, values...)
+	}
+	return root, nil
+}
+
+// sameKey compares keys using the key normalizer, when it is set
+func (p extractor) sameKey(key, other string) bool {
+	return key == other || p.opts.normalizer != nil && p.opts.normalizer(key) == p.opts.normalizer(other)
+}
+
+// bindNode sets value of type t to values of node and its children
+func (p extractor) bindNode(value reflect.Value, t reflect.Type, node *bracketNode, prm param) error {
+	prm.key = node.key
+	if c, ok := p.opts.converter(t); ok {
+		if len(node.values) == 0 && len(node.children) > 0 {
+			return ErrorUnmarshalType{prm.fieldError(
+				fmt.Errorf(`error unmarshalling bracket notation keys of "%v" into %v`, node.key, t))}
+		}
+		if len(node.values) == 0 {
+			return nil
+		}
+		str, err := p.single(prm, node.values)
+		if err != nil {
+			return err
+		}
+		converted, err := c.convertTo(prm, t, str)
+		if err != nil {
+			return err
+		}
+		value.Set(converted)
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return p.bindStructNode(value, t, node, prm)
+	case reflect.Map:
+		return p.bindMapNode(value, t, node, prm)
+	case reflect.Slice:
+		return p.bindSliceNode(value, t, node, prm)
+	case reflect.Ptr:
+		// pointers are set only for present keys, so absent keys are told apart from zero values
+		elem := reflect.New(t.Elem())
+		err := p.bindNode(elem.Elem(), t.Elem(), node, prm)
+		if err != nil {
+			return err
+		}
+		value.Set(elem)
+		return nil
+	default:
+		return ErrorUnSupportedParamType{prm.fieldError(
+			fmt.Errorf(`error unmarshalling "%v" into %v, unsupported param type`, node.key, t))}
+	}
+}
+
+func (p extractor) bindStructNode(value reflect.Value, t reflect.Type, node *bracketNode, prm param) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := p.opts.fieldTag(field)
+		if !ok {
+			continue
+		}
+
+		for _, key := range tag.keys() {
+			child := p.childNode(node, key)
+			if child == nil {
+				continue
+			}
+			nested := param{field: field, path: prm.path + `.` + field.Name, keys: tag.keys(), in: prm.in, tag: tag}
+			err := p.bindNode(value.Field(i), field.Type, child, nested)
+			if err != nil {
+				return err
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// childNode returns the child of node having the segment key, using the key normalizer when it is set
+func (p extractor) childNode(node *bracketNode, key string) *bracketNode {
+	if child, ok := node.children[key]; ok {
+		return child
+	}
+	if p.opts.normalizer == nil {
+		return nil
+	}
+	for _, segment := range node.sortedChildren() {
+		if p.sameKey(segment, key) {
+			return node.children[segment]
+		}
+	}
+	return nil
+}
+
+func (p extractor) bindMapNode(value reflect.Value, t reflect.Type, node *bracketNode, prm param) error {
+	if t.Key().Kind() != reflect.String {
+		return ErrorUnSupportedParamType{prm.fieldError(
+			fmt.Errorf(`error unmarshalling "%v" into %v, map key should be a string`, node.key, t))}
+	}
+
+	m := reflect.MakeMapWithSize(t, len(node.children))
+	for _, segment := range node.sortedChildren() {
+		elem := reflect.New(t.Elem()).Elem()
+		err := p.bindNode(elem, t.Elem(), node.children[segment], prm)
+		if err != nil {
+			return err
+		}
+		m.SetMapIndex(reflect.ValueOf(segment).Convert(t.Key()), elem)
+	}
+	value.Set(m)
+	return nil
+}
+
+// bindSliceNode binds indexed children, e.g. items[0][sku], into their index. Values of
+// empty brackets, e.g. items[]=a, are appended to slices of values having a converter
+func (p extractor) bindSliceNode(value reflect.Value, t reflect.Type, node *bracketNode, prm param) error {
+	length := 0
+	indices := map[int]*bracketNode{}
+	for segment, child := range node.children {
+		index, err := strconv.Atoi(segment)
+		if err != nil || index < 0 {
+			continue
+		}
+		if index > p.opts.maxIndex {
+			prm.key = child.key
+			return ErrorLimitExceeded{prm.fieldError(
+				fmt.Errorf(`index of key exceeds the limit of %d`, p.opts.maxIndex))}
+		}
+		indices[index] = child
+		if index >= length {
+			length = index + 1
+		}
+	}
+
+	slice := reflect.MakeSlice(t, length, length)
+	for index, child := range indices {
+		err := p.bindNode(slice.Index(index), t.Elem(), child, prm)
+		if err != nil {
+			return err
+		}
+	}
+
+	appended, ok := node.children[``]
+	if c, converts := p.opts.converter(t.Elem()); ok && converts {
+		prm.key = appended.key
+		for _, str := range appended.values {
+			converted, err := c.convertTo(prm, t.Elem(), str)
+			if err != nil {
+				return err
+			}
+			slice = reflect.Append(slice, converted)
+		}
+	}
+
+	value.Set(slice)
+	return nil
+}
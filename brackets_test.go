@@ -0,0 +1,183 @@
+package paramex
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type bracketOwner struct {
+	ID   int    `param:"id"`
+	Name string `param:"name"`
+}
+
+type bracketFilter struct {
+	Status string         `param:"status"`
+	Owner  bracketOwner   `param:"owner"`
+	Tags   []string       `param:"tags"`
+	Extra  map[string]int `param:"extra"`
+}
+
+type bracketItem struct {
+	SKU      string `param:"sku"`
+	Quantity int    `param:"qty"`
+}
+
+type bracketParams struct {
+	Filter bracketFilter     `param:"filter"`
+	Items  []bracketItem     `param:"items"`
+	Sort   map[string]string `param:"sort"`
+	IDs    []int             `param:"ids"`
+	Name   string            `param:"name"`
+}
+
+func TestExtractor_BracketNotation(t *testing.T) {
+	t.Run(`test nested structs, maps and slices`, func(t *testing.T) {
+		query := `filter[status]=open&filter[owner][id]=5&filter[owner][name]=nipuna&filter[tags][]=a&filter[tags][]=b` +
+			`&filter[extra][x]=1&items[0][sku]=a&items[0][qty]=2&items[2][sku]=c&sort[name]=asc&ids[1]=20&ids[0]=10&name=test`
+		req, err := http.NewRequest(`GET`, `https://nipuna.lk?`+query, nil)
+		if err != nil {
+			t.Fatal(`error creating request`, err)
+		}
+
+		obj := bracketParams{}
+		result, err := NewParamExtractor(WithStrict()).Bind(&obj, req, InQuery)
+		if err != nil {
+			t.Fatalf(`error extracting queries due to %v`, err)
+		}
+		expected := bracketParams{
+			Filter: bracketFilter{
+				Status: `open`,
+				Owner:  bracketOwner{ID: 5, Name: `nipuna`},
+				Tags:   []string{`a`, `b`},
+				Extra:  map[string]int{`x`: 1},
+			},
+			Items: []bracketItem{{SKU: `a`, Quantity: 2}, {}, {SKU: `c`}},
+			Sort:  map[string]string{`name`: `asc`},
+			IDs:   []int{10, 20},
+			Name:  `test`,
+		}
+		if !reflect.DeepEqual(obj, expected) {
+			t.Errorf(`expected [%+v], but received [%+v]`, expected, obj)
+		}
+		if len(result.Params) != 5 || result.Params[0].Key != `filter` {
+			t.Errorf(`unexpected params %+v`, result.Params)
+		}
+	})
+
+	t.Run(`test forms`, func(t *testing.T) {
+		form := url.Values{}
+		form.Set(`filter[owner][id]`, `7`)
+		form.Set(`Items[0][SKU]`, `x`)
+		req, err := http.NewRequest(`POST`, `https://nipuna.lk`, strings.NewReader(form.Encode()))
+		if err != nil {
+			t.Fatal(`error creating request`, err)
+		}
+		req.Header.Set(`Content-Type`, `application/x-www-form-urlencoded`)
+
+		obj := bracketParams{}
+		err = NewParamExtractor(WithCaseInsensitiveKeys()).ExtractForms(&obj, req)
+		if err != nil {
+			t.Fatalf(`error extracting forms due to %v`, err)
+		}
+		if obj.Filter.Owner.ID != 7 || len(obj.Items) != 1 || obj.Items[0].SKU != `x` {
+			t.Errorf(`unexpected extracted values [%+v]`, obj)
+		}
+	})
+
+	t.Run(`test errors`, func(t *testing.T) {
+		tests := []struct {
+			query string
+			opts  []Option
+			err   interface{}
+			field string
+			name  string
+		}{
+			{`filter[owner][id]=five`, nil, ErrorUnmarshalType{}, `Filter.Owner.ID`, `filter[owner][id]`},
+			{`items[101][sku]=a`, nil, ErrorLimitExceeded{}, `Items`, `items[101]`},
+			{`items[3][sku]=a`, []Option{WithMaxIndex(2)}, ErrorLimitExceeded{}, `Items`, `items[3]`},
+			{`filter[a][b][c][d][e][f]=1`, nil, ErrorLimitExceeded{}, `Filter`, `filter[a][b][c][d][e][f]`},
+			{`filter[owner][id]=1`, []Option{WithMaxDepth(1)}, ErrorLimitExceeded{}, `Filter`, `filter[owner][id]`},
+		}
+		for _, test := range tests {
+			req, err := http.NewRequest(`GET`, `https://nipuna.lk?`+test.query, nil)
+			if err != nil {
+				t.Fatal(`error creating request`, err)
+			}
+			err = NewParamExtractor(test.opts...).ExtractQueries(&bracketParams{}, req)
+			if reflect.TypeOf(err) != reflect.TypeOf(test.err) {
+				t.Errorf(`expected %T for "%s", but received %v`, test.err, test.query, err)
+				continue
+			}
+			fieldErr := &FieldError{}
+			if !errors.As(err, &fieldErr) || fieldErr.Field != test.field || fieldErr.Name != test.name {
+				t.Errorf(`expected error of [%s %s], but received [%+v]`, test.field, test.name, fieldErr)
+			}
+		}
+	})
+
+	t.Run(`test scalar fields`, func(t *testing.T) {
+		req, err := http.NewRequest(`GET`, `https://nipuna.lk?name[x]=a&limit[]=5`, nil)
+		if err != nil {
+			t.Fatal(`error creating request`, err)
+		}
+		obj := struct {
+			Name  string `param:"name,required"`
+			Limit int    `param:"limit"`
+		}{}
+		err = NewParamExtractor().ExtractQueries(&obj, req)
+		if !errors.As(err, &ErrorRequiredParam{}) {
+			t.Errorf(`expected ErrorRequiredParam, but received %v`, err)
+		}
+
+		req, err = http.NewRequest(`GET`, `https://nipuna.lk?name[x]=`, nil)
+		if err != nil {
+			t.Fatal(`error creating request`, err)
+		}
+		err = NewParamExtractor(WithStrict()).ExtractQueries(&bracketParams{}, req)
+		unknownErr := ErrorUnknownParams{}
+		if !errors.As(err, &unknownErr) || !reflect.DeepEqual(unknownErr.Keys, []string{`name[x]`}) {
+			t.Errorf(`expected unknown param "name[x]", but received %v`, err)
+		}
+	})
+
+	t.Run(`test headers are not parsed`, func(t *testing.T) {
+		req, err := http.NewRequest(`GET`, `https://nipuna.lk`, nil)
+		if err != nil {
+			t.Fatal(`error creating request`, err)
+		}
+		req.Header.Set(`filter[status]`, `open`)
+		obj := bracketParams{}
+		err = NewParamExtractor().ExtractHeaders(&obj, req)
+		if err != nil || obj.Filter.Status != `` {
+			t.Errorf(`unexpected result [%+v], %v`, obj, err)
+		}
+	})
+}
+
+func Test_parseBracketKey(t *testing.T) {
+	tests := []struct {
+		key      string
+		base     string
+		segments []string
+		ok       bool
+	}{
+		{`name`, ``, nil, false},
+		{`[name]`, ``, nil, false},
+		{`filter[status`, ``, nil, false},
+		{`filter[status]x`, ``, nil, false},
+		{`filter[status]`, `filter`, []string{`status`}, true},
+		{`items[0][sku]`, `items`, []string{`0`, `sku`}, true},
+		{`tags[]`, `tags`, []string{``}, true},
+	}
+	for _, test := range tests {
+		base, segments, ok := parseBracketKey(test.key)
+		if base != test.base || !reflect.DeepEqual(segments, test.segments) || ok != test.ok {
+			t.Errorf(`expected [%s %v %t] for "%s", but received [%s %v %t]`,
+				test.base, test.segments, test.ok, test.key, base, segments, ok)
+		}
+	}
+}
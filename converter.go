@@ -0,0 +1,173 @@
+package paramex
+
+import (
+	"encoding"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConverterFunc converts a parameter value into a value of the type it is registered for
+type ConverterFunc func(value string) (interface{}, error)
+
+type converter struct {
+	// name is the type name used in error messages
+	name    string
+	convert ConverterFunc
+}
+
+var (
+	stringType  = reflect.TypeOf(``)
+	boolType    = reflect.TypeOf(true)
+	int32Type   = reflect.TypeOf(int32(0))
+	intType     = reflect.TypeOf(int(0))
+	int64Type   = reflect.TypeOf(int64(0))
+	float32Type = reflect.TypeOf(float32(0))
+	float64Type = reflect.TypeOf(float64(0))
+	uuidType    = reflect.TypeOf(uuid.UUID{})
+	retryType   = reflect.TypeOf(RetryAfter{})
+	timeType    = reflect.TypeOf(time.Time{})
+)
+
+var defaultConverters = map[reflect.Type]converter{
+	stringType: {`string`, func(value string) (interface{}, error) {
+		return value, nil
+	}},
+	boolType: {`bool`, func(value string) (interface{}, error) {
+		return strconv.ParseBool(value)
+	}},
+	int32Type: {`int32`, func(value string) (interface{}, error) {
+		v, err := strconv.Atoi(value)
+		return int32(v), err
+	}},
+	intType: {`int`, func(value string) (interface{}, error) {
+		return strconv.Atoi(value)
+	}},
+	int64Type: {`int64`, func(value string) (interface{}, error) {
+		return strconv.ParseInt(value, 10, 64)
+	}},
+	float32Type: {`float32`, func(value string) (interface{}, error) {
+		v, err := strconv.ParseFloat(value, 32)
+		return float32(v), err
+	}},
+	float64Type: {`float64`, func(value string) (interface{}, error) {
+		return strconv.ParseFloat(value, 64)
+	}},
+	uuidType: {`uuid`, func(value string) (interface{}, error) {
+		return uuid.Parse(value)
+	}},
+	retryType: {`retry after`, func(value string) (interface{}, error) {
+		return parseRetryAfter(value, time.Now())
+	}},
+	timeType: {`time`, func(value string) (interface{}, error) {
+		return time.Parse(time.RFC3339, value)
+	}},
+}
+
+// converter returns the converter of t, preferring converters registered with WithConverter
+func (o options) converter(t reflect.Type) (converter, bool) {
+	if c, ok := o.converters[t]; ok {
+		return c, true
+	}
+	c, ok := defaultConverters[t]
+	return c, ok
+}
+
+// convertTo converts str of prm into a value assignable to a t typed variable
+func (c converter) convertTo(prm param, t reflect.Type, str string) (reflect.Value, error) {
+	converted, err := c.convert(str)
+	if err != nil {
+		return reflect.Value{}, ErrorUnmarshalType{prm.fieldError(
+			fmt.Errorf(`error unmarshalling [%v] into [%v] due to %v`, str, c.name, err))}
+	}
+
+	value := reflect.ValueOf(converted)
+	switch {
+	case !value.IsValid():
+		return reflect.Zero(t), nil
+	case value.Type().AssignableTo(t):
+		return value, nil
+	case value.Type().ConvertibleTo(t):
+		return value.Convert(t), nil
+	default:
+		return reflect.Value{}, ErrorUnSupportedParamType{prm.fieldError(
+			fmt.Errorf(`converter of [%v] returned unassignable type [%v]`, c.name, value.Type()))}
+	}
+}
+
+// FormatterFunc formats a field value into a parameter value, which is the inverse of a ConverterFunc
+type FormatterFunc func(value interface{}) (string, error)
+
+var defaultFormatters = map[reflect.Type]FormatterFunc{
+	stringType: func(value interface{}) (string, error) {
+		return value.(string), nil
+	},
+	boolType: func(value interface{}) (string, error) {
+		return strconv.FormatBool(value.(bool)), nil
+	},
+	int32Type: func(value interface{}) (string, error) {
+		return strconv.FormatInt(int64(value.(int32)), 10), nil
+	},
+	intType: func(value interface{}) (string, error) {
+		return strconv.Itoa(value.(int)), nil
+	},
+	int64Type: func(value interface{}) (string, error) {
+		return strconv.FormatInt(value.(int64), 10), nil
+	},
+	float32Type: func(value interface{}) (string, error) {
+		return strconv.FormatFloat(float64(value.(float32)), 'g', -1, 32), nil
+	},
+	float64Type: func(value interface{}) (string, error) {
+		return strconv.FormatFloat(value.(float64), 'g', -1, 64), nil
+	},
+	uuidType: func(value interface{}) (string, error) {
+		return value.(uuid.UUID).String(), nil
+	},
+	retryType: func(value interface{}) (string, error) {
+		return time.Time(value.(RetryAfter)).UTC().Format(http.TimeFormat), nil
+	},
+	timeType: func(value interface{}) (string, error) {
+		return value.(time.Time).Format(time.RFC3339Nano), nil
+	},
+}
+
+// formatter returns the formatter of t, preferring formatters registered with WithFormatter.
+// Types having only a converter registered with WithConverter are formatted by
+// their encoding.TextMarshaler implementation, or else by fmt.Sprint
+func (o options) formatter(t reflect.Type) (FormatterFunc, bool) {
+	if f, ok := o.formatters[t]; ok {
+		return f, true
+	}
+	if f, ok := defaultFormatters[t]; ok {
+		return f, true
+	}
+	if _, ok := o.converters[t]; !ok {
+		return nil, false
+	}
+	return func(value interface{}) (string, error) {
+		if m, ok := value.(encoding.TextMarshaler); ok {
+			text, err := m.MarshalText()
+			return string(text), err
+		}
+		return fmt.Sprint(value), nil
+	}, true
+}
+
+// format formats value of prm into a parameter value
+func (o options) format(prm param, value reflect.Value) (string, error) {
+	f, ok := o.formatter(value.Type())
+	if !ok {
+		return ``, ErrorUnSupportedParamType{prm.fieldError(
+			fmt.Errorf(`error marshalling %v of "%v", unsupported param type`, value.Type(), prm.key))}
+	}
+	str, err := f(value.Interface())
+	if err != nil {
+		return ``, ErrorMarshalType{prm.fieldError(
+			fmt.Errorf(`error marshalling [%v] of [%v] due to %v`, value.Interface(), value.Type(), err))}
+	}
+	return str, nil
+}
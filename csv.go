@@ -0,0 +1,185 @@
+package paramex
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// RecordError locates a failure of a CSV record. Err is the paramex error of the failure,
+// e.g. ErrorUnmarshalType wrapping a *FieldError named by the column header
+type RecordError struct {
+	// Row is the line number of the record, the header row is line 1
+	Row int
+	// Column is the column number of the failed value starting from 1, or 0 when the whole record failed
+	Column int
+	Err    error
+}
+
+func (e *RecordError) Error() string {
+	if e.Column == 0 {
+		return fmt.Sprintf(`row %d: %v`, e.Row, e.Err)
+	}
+	return fmt.Sprintf(`row %d, column %d: %v`, e.Row, e.Column, e.Err)
+}
+
+// Unwrap returns the paramex error of the failure
+func (e *RecordError) Unwrap() error { return e.Err }
+
+// StatusCode returns the status code of the paramex error of the failure
+func (e *RecordError) StatusCode() int { return statusCode(e.Err) }
+
+// DecodeCSV reads records of a CSV body having a header row and binds every record into a T, which should be
+// a Go struct annotated with param, calling fn in the order of the records. Columns are matched by their header
+// to field keys, and empty values are absent. Records are streamed, therefore large bodies are not read into memory.
+//
+// Failed records return a *RecordError, or Errors of every failed record when WithErrorAggregation is set.
+// Errors returned by fn stop reading and are returned as is
+func DecodeCSV[T any](body io.Reader, fn func(record T) error, opts ...Option) error {
+	p := extractor{opts: newOptions(opts)}
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return ErrorUnSupportedType{fmt.Errorf(`type of %v is not extractable, required struct object`, t)}
+	}
+	return p.readCSV(body, t, func(value reflect.Value) error {
+		return fn(value.Elem().Interface().(T))
+	})
+}
+
+// decodeCSV appends records of a text/csv body into the body field, which should be a slice of Go structs
+// or Go struct references
+func decodeCSV(p extractor, _ *http.Request, body io.Reader, prm param, value reflect.Value) error {
+	t := prm.field.Type
+	if t.Kind() != reflect.Slice || t.Elem().Kind() != reflect.Struct &&
+		!(t.Elem().Kind() == reflect.Ptr && t.Elem().Elem().Kind() == reflect.Struct) {
+		return ErrorUnSupportedParamType{prm.fieldError(
+			fmt.Errorf(`error unmarshalling CSV body into %v, required a slice of structs`, t))}
+	}
+
+	elem := t.Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	records := value.Elem()
+	err := p.readCSV(body, elem, func(record reflect.Value) error {
+		if t.Elem().Kind() != reflect.Ptr {
+			record = record.Elem()
+		}
+		records.Set(reflect.Append(records, record))
+		return nil
+	})
+	if err == nil && records.Len() == 0 {
+		return io.EOF
+	}
+	return err
+}
+
+// readCSV binds records of body into new values of the struct type t and calls fn with their references
+func (p extractor) readCSV(body io.Reader, t reflect.Type, fn func(value reflect.Value) error) error {
+	reader := csv.NewReader(body)
+	reader.ReuseRecord = true
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return csvError(err)
+	}
+	// the header is copied since records are reused, and a byte order mark is trimmed
+	header = append([]string{}, header...)
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	columns := map[string]int{}
+	for i, name := range header {
+		if _, ok := columns[name]; !ok {
+			columns[name] = i + 1
+		}
+	}
+
+	var errs Errors
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return csvError(err)
+		}
+
+		row, _ := reader.FieldPos(0)
+		value := reflect.New(t)
+		_, err = p.extract(value.Interface(), InBody, csvSource{header: header, record: record})
+		if err == nil {
+			err = fn(value)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !p.opts.aggregateErrors {
+			return p.opts.withStatus(recordError(err, row, columns))
+		}
+		nested, ok := err.(Errors)
+		if !ok {
+			nested = Errors{err}
+		}
+		for _, err := range nested {
+			errs = append(errs, p.opts.withStatus(recordError(err, row, columns)))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// recordError locates err of the record at row by the column of the failed key
+func recordError(err error, row int, columns map[string]int) error {
+	recordErr := &RecordError{Row: row, Err: err}
+	fieldErr := &FieldError{}
+	if errors.As(err, &fieldErr) {
+		recordErr.Column = columns[fieldErr.Name]
+	}
+	return recordErr
+}
+
+// csvError converts errors of reading CSV bodies into paramex errors
+func csvError(err error) error {
+	parseErr := &csv.ParseError{}
+	if errors.As(err, &parseErr) {
+		return &RecordError{Row: parseErr.Line, Column: parseErr.Column,
+			Err: ErrorMalformedRequest{fmt.Errorf(`invalid CSV body due to %v`, parseErr.Err)}}
+	}
+	return decodeError(`CSV`, err)
+}
+
+// csvSource looks up values of a CSV record by the column headers. Empty values are absent,
+// and columns having the same header are multiple values
+type csvSource struct {
+	header []string
+	record []string
+}
+
+func (s csvSource) Lookup(key string) ([]string, bool) {
+	var values []string
+	for i, name := range s.header {
+		if name == key && i < len(s.record) && s.record[i] != `` {
+			values = append(values, s.record[i])
+		}
+	}
+	return values, len(values) > 0
+}
+
+func (s csvSource) Keys() []string {
+	keys := make([]string, 0, len(s.header))
+	for i, name := range s.header {
+		if i < len(s.record) && s.record[i] != `` {
+			keys = append(keys, name)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,163 @@
+package paramex
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type importedUser struct {
+	ID     int      `param:"id,required"`
+	Name   string   `param:"name|full_name"`
+	Active bool     `param:"active,default=true"`
+	Tags   []string `param:"tag"`
+}
+
+type importUsers struct {
+	DryRun bool           `param:"dry_run"`
+	Users  []importedUser `param:",body,required"`
+}
+
+func TestDecodeCSV(t *testing.T) {
+	t.Run(`test records`, func(t *testing.T) {
+		body := "\ufeffid,full_name,active,tag,tag\n1,nipuna,false,a,b\n2,,,,\n"
+		var users []importedUser
+		err := DecodeCSV(strings.NewReader(body), func(user importedUser) error {
+			users = append(users, user)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []importedUser{{ID: 1, Name: `nipuna`, Tags: []string{`a`, `b`}}, {ID: 2, Active: true}}
+		if !reflect.DeepEqual(users, expected) {
+			t.Errorf(`expected %v, but received %v`, expected, users)
+		}
+	})
+
+	t.Run(`test stopping by callback`, func(t *testing.T) {
+		stop := errors.New(`stop`)
+		count := 0
+		err := DecodeCSV(strings.NewReader("id\n1\n2\n"), func(user importedUser) error {
+			count++
+			return stop
+		})
+		if err != stop || count != 1 {
+			t.Errorf(`expected a single record and the callback error, but received %d records and %v`, count, err)
+		}
+	})
+
+	tests := []struct {
+		name   string
+		body   string
+		row    int
+		column int
+		err    error
+	}{
+		{`test invalid value`, "name,id\nnipuna,1\nsenpathi,one\n", 3, 2, ErrorUnmarshalType{}},
+		{`test required value`, "id,name\n,nipuna\n", 2, 1, ErrorRequiredParam{}},
+		{`test invalid csv`, "id,name\n1,ni\"puna\n", 2, 5, ErrorMalformedRequest{}},
+		{`test field count`, "id,name\n1\n", 2, 1, ErrorMalformedRequest{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := DecodeCSV(strings.NewReader(test.body), func(importedUser) error { return nil })
+			recordErr := &RecordError{}
+			if !errors.As(err, &recordErr) {
+				t.Fatalf(`expected a record error, but received %v`, err)
+			}
+			if recordErr.Row != test.row || recordErr.Column != test.column {
+				t.Errorf(`expected row %d, column %d, but received row %d, column %d`,
+					test.row, test.column, recordErr.Row, recordErr.Column)
+			}
+			if reflect.TypeOf(recordErr.Err) != reflect.TypeOf(test.err) {
+				t.Errorf(`expected %T, but received %T`, test.err, recordErr.Err)
+			}
+			if statusCode(err) != http.StatusBadRequest {
+				t.Errorf(`expected status %d, but received %d`, http.StatusBadRequest, statusCode(err))
+			}
+		})
+	}
+
+	t.Run(`test error aggregation`, func(t *testing.T) {
+		body := "id,active\none,yes\n2,true\nthree,\n"
+		err := DecodeCSV(strings.NewReader(body), func(importedUser) error { return nil }, WithErrorAggregation())
+		var errs Errors
+		if !errors.As(err, &errs) || len(errs) != 3 {
+			t.Fatalf(`expected 3 errors, but received %v`, err)
+		}
+		expected := []string{`row 2, column 1`, `row 2, column 2`, `row 4, column 1`}
+		for i, err := range errs {
+			if !strings.HasPrefix(err.Error(), expected[i]) {
+				t.Errorf(`expected %v, but received %v`, expected[i], err)
+			}
+		}
+	})
+
+	t.Run(`test unsupported type`, func(t *testing.T) {
+		err := DecodeCSV(strings.NewReader("id\n1\n"), func(int) error { return nil })
+		if !errors.As(err, &ErrorUnSupportedType{}) {
+			t.Errorf(`expected ErrorUnSupportedType, but received %v`, err)
+		}
+	})
+}
+
+func TestExtractor_ExtractBody_CSV(t *testing.T) {
+	extractor := NewParamExtractor()
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, `https://nipuna.lk/users?dry_run=true`, strings.NewReader(body))
+		req.Header.Set(`Content-Type`, `text/csv; charset=utf-8`)
+		return req
+	}
+
+	t.Run(`test records`, func(t *testing.T) {
+		params := importUsers{}
+		req := newRequest("id,name\n1,nipuna\n2,senpathi\n")
+		err := extractor.ExtractQueries(&params, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = extractor.ExtractBody(&params, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := importUsers{DryRun: true, Users: []importedUser{
+			{ID: 1, Name: `nipuna`, Active: true}, {ID: 2, Name: `senpathi`, Active: true}}}
+		if !reflect.DeepEqual(params, expected) {
+			t.Errorf(`expected %v, but received %v`, expected, params)
+		}
+	})
+
+	t.Run(`test references`, func(t *testing.T) {
+		params := struct {
+			Body []*importedUser
+		}{}
+		err := extractor.ExtractBody(&params, newRequest("id\n1\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(params.Body) != 1 || params.Body[0].ID != 1 {
+			t.Errorf(`expected a record of id 1, but received %v`, params.Body)
+		}
+	})
+
+	t.Run(`test header only`, func(t *testing.T) {
+		err := extractor.ExtractBody(&importUsers{}, newRequest("id,name\n"))
+		if !errors.As(err, &ErrorRequiredParam{}) {
+			t.Errorf(`expected ErrorRequiredParam, but received %v`, err)
+		}
+	})
+
+	t.Run(`test unsupported body type`, func(t *testing.T) {
+		params := struct {
+			Body []string
+		}{}
+		err := extractor.ExtractBody(&params, newRequest("id\n1\n"))
+		if !errors.As(err, &ErrorUnSupportedParamType{}) {
+			t.Errorf(`expected ErrorUnSupportedParamType, but received %v`, err)
+		}
+	})
+}
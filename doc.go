@@ -66,6 +66,12 @@
 //  - int64
 //  - float32
 //  - float64
-// 	- []string (only for form values and query values)
 //  - https://github.com/google/uuid
+//  - paramex.RetryAfter, parsed from delay seconds or an HTTP date
+//  - time.Time, parsed as RFC 3339, e.g. 2024-05-01T10:00:00Z
+//  - slices of above types, e.g. []string. Header and path slices are comma separated by default
+//  - maps with string keys capturing keys by a prefix, e.g. `param:"meta.*"` or `param:"X-Meta-,prefix"`
+//
+// Arrays and objects serialized in OpenAPI 3 styles are decoded by the style and explode tag options,
+// e.g. `param:"color,style=pipeDelimited"` or `param:"color,style=matrix,explode"`
 package paramex
@@ -0,0 +1,182 @@
+package paramex
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// SchemaParam is a parameter of a ParamSchema, which describes the same as a tagged struct field
+type SchemaParam struct {
+	// Name is the key of the parameter, and alias keys separated by |, e.g. user_id|userId
+	Name string
+	// In is the location of the parameter
+	In In
+	// Type is the Go type of values, e.g. reflect.TypeOf(int64(0)), which is bound the same as a field type
+	Type reflect.Type
+	// Required fails binding with ErrorRequiredParam when the parameter is absent
+	Required bool
+	// Default is the value of the absent parameter, same as the default tag option. Empty defaults are not set
+	Default string
+	// Rules are tag options constraining values, e.g. min=1, enum=asc|desc, pattern=^[a-z]+$ or deprecated.
+	// Commas of rules are escaped, since every rule is a single option
+	Rules []string
+}
+
+// tag returns the field tag of the parameter
+func (sp SchemaParam) tag() string {
+	parts := []string{escapeTag(sp.Name, ','), `in=` + string(sp.In)}
+	if sp.Required {
+		parts = append(parts, `required`)
+	}
+	if sp.Default != `` {
+		parts = append(parts, `default=`+escapeTag(sp.Default, ','))
+	}
+	for _, rule := range sp.Rules {
+		parts = append(parts, escapeTag(rule, ','))
+	}
+	return strings.Join(parts, `,`)
+}
+
+// ParamSchema binds parameters defined at runtime, e.g. by configuration, into map[string]interface{} values
+// without declaring a Go struct. It is safe for concurrent use
+type ParamSchema struct {
+	opts   options
+	typ    reflect.Type
+	params []SchemaParam
+	ins    []In
+}
+
+// NewParamSchema returns a schema of params, which are bound using the same conversion rules and errors as
+// an Extractor created by the same options. Invalid params fail with ErrorUnSupportedParamType
+func NewParamSchema(params []SchemaParam, opts ...Option) (*ParamSchema, error) {
+	s := &ParamSchema{opts: newOptions(opts), params: params}
+	keys := map[string]bool{}
+	located := map[In]bool{}
+	fields := make([]reflect.StructField, 0, len(params))
+	for i, sp := range params {
+		if sp.Name == `` || sp.Type == nil {
+			return nil, ErrorUnSupportedParamType{fmt.Errorf(`param %d requires a name and a type`, i)}
+		}
+		tag := parseTag(sp.tag())
+		key := tag.keys()[0]
+		if keys[key] {
+			return nil, ErrorUnSupportedParamType{fmt.Errorf(`duplicate param "%v"`, key)}
+		}
+		keys[key] = true
+		if _, err := tag.constraints(); err != nil {
+			return nil, ErrorUnSupportedParamType{fmt.Errorf(`invalid rules of param "%v" due to %v`, key, err)}
+		}
+
+		located[sp.In] = true
+		fields = append(fields, s.opts.dynamicField(i, sp.Type, sp.tag()))
+	}
+
+	for _, in := range []In{InPath, InQuery, InForm, InHeader, InCookie} {
+		if located[in] {
+			s.ins = append(s.ins, in)
+			delete(located, in)
+		}
+	}
+	for in := range located {
+		return nil, ErrorUnSupportedParamType{fmt.Errorf(`unsupported param location "%v"`, in)}
+	}
+	s.typ = reflect.StructOf(fields)
+	return s, nil
+}
+
+// Bind binds params of req into a map of present params and params having a default value by their keys.
+// Values are typed by the param types, and objects bound into structs are map[string]interface{} values
+// having every field, except nil pointer fields. Pointer fields are set only for present properties
+func (s *ParamSchema) Bind(req *http.Request) (map[string]interface{}, error) {
+	value := reflect.New(s.typ)
+	bound, _, err := extractor{opts: s.opts}.bindDynamic(value, req, s.ins)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]interface{}{}
+	for i, sp := range s.params {
+		if !bound[s.typ.Field(i).Name] && sp.Default == `` {
+			continue
+		}
+		values[parseTag(sp.tag()).keys()[0]] = s.opts.plain(value.Elem().Field(i))
+	}
+	return values, nil
+}
+
+// dynamicField returns the i th field of a struct type created at runtime, having the field tag tag
+func (o options) dynamicField(i int, t reflect.Type, tag string) reflect.StructField {
+	return reflect.StructField{
+		Name: `P` + strconv.Itoa(i),
+		Type: t,
+		Tag:  reflect.StructTag(o.tagName + `:` + strconv.Quote(tag)),
+	}
+}
+
+// bindDynamic binds params of the ins locations of req into v, a reference of a struct created at runtime,
+// returning names of bound fields and warnings. Errors are aggregated when WithErrorAggregation is set
+func (p extractor) bindDynamic(v reflect.Value, req *http.Request, ins []In) (map[string]bool, []Warning, error) {
+	bound := map[string]bool{}
+	var warnings []Warning
+	var errs Errors
+	for _, in := range ins {
+		result, err := p.Bind(v.Interface(), req, in)
+		if result != nil {
+			warnings = append(warnings, result.Warnings...)
+			for _, prm := range result.Params {
+				bound[prm.Field] = true
+			}
+		}
+		if err == nil {
+			continue
+		}
+		if !p.opts.aggregateErrors {
+			return nil, nil, err
+		}
+		if nested, ok := err.(Errors); ok {
+			errs = append(errs, nested...)
+			continue
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, nil, errs
+	}
+	return bound, warnings, nil
+}
+
+// plain converts values of structs created at runtime into map[string]interface{} values.
+// Nil pointers are absent properties, which are omitted
+func (o options) plain(value reflect.Value) interface{} {
+	switch value.Kind() {
+	case reflect.Struct:
+		if _, ok := o.converter(value.Type()); ok {
+			return value.Interface()
+		}
+		m := map[string]interface{}{}
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Field(i)
+			tag, ok := o.fieldTag(value.Type().Field(i))
+			if ok && !(field.Kind() == reflect.Ptr && field.IsNil()) {
+				m[tag.keys()[0]] = o.plain(field)
+			}
+		}
+		return m
+	case reflect.Ptr:
+		if value.IsNil() {
+			return nil
+		}
+		return o.plain(value.Elem())
+	case reflect.Map:
+		m := map[string]interface{}{}
+		for _, key := range value.MapKeys() {
+			m[key.String()] = o.plain(value.MapIndex(key))
+		}
+		return m
+	default:
+		return value.Interface()
+	}
+}
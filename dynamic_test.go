@@ -0,0 +1,93 @@
+package paramex
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// schemaFilter has pointer fields, which are omitted when their properties are absent
+type schemaFilter struct {
+	Owner  int64   `param:"owner"`
+	Active *bool   `param:"active"`
+	Status *string `param:"status"`
+}
+
+func TestParamSchema(t *testing.T) {
+	schema, err := NewParamSchema([]SchemaParam{
+		{Name: `id`, In: InPath, Type: reflect.TypeOf(int64(0)), Rules: []string{`min=1`}},
+		{Name: `sort|order`, In: InQuery, Type: reflect.TypeOf(``), Default: `asc`, Rules: []string{`enum=asc|desc`}},
+		{Name: `tags`, In: InQuery, Type: reflect.TypeOf([]string{}), Rules: []string{`maxItems=2`}},
+		{Name: `code`, In: InQuery, Type: reflect.TypeOf(``), Rules: []string{`pattern=^[A-Z]{1,3}$`}},
+		{Name: `filter`, In: InQuery, Type: reflect.TypeOf(schemaFilter{})},
+		{Name: `X-Request-Id`, In: InHeader, Type: reflect.TypeOf(uuid.UUID{}), Required: true},
+	}, WithPathValues(func(_ *http.Request, name string) string {
+		return map[string]string{`id`: `42`}[name]
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run(`test bind`, func(t *testing.T) {
+		id := uuid.New()
+		req, _ := http.NewRequest(`GET`, `https://nipuna.lk/users/42?tags=a&tags=b&code=AB&filter[owner]=0&filter[active]=false`, nil)
+		req.Header.Set(`X-Request-Id`, id.String())
+
+		values, err := schema.Bind(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := map[string]interface{}{
+			`id`:           int64(42),
+			`sort`:         `asc`,
+			`tags`:         []string{`a`, `b`},
+			`code`:         `AB`,
+			`filter`:       map[string]interface{}{`owner`: int64(0), `active`: false},
+			`X-Request-Id`: id,
+		}
+		if !reflect.DeepEqual(values, expected) {
+			t.Errorf(`expected values %v, but received %v`, expected, values)
+		}
+	})
+
+	t.Run(`test errors`, func(t *testing.T) {
+		tests := []struct {
+			query  string
+			header bool
+			err    interface{}
+		}{
+			{`sort=up`, true, ErrorInvalidValue{}},
+			{`tags=a&tags=b&tags=c`, true, ErrorInvalidValue{}},
+			{`code=ABCD`, true, ErrorInvalidValue{}},
+			{`filter[owner]=me`, true, ErrorUnmarshalType{}},
+			{``, false, ErrorRequiredParam{}},
+		}
+		for _, test := range tests {
+			req, _ := http.NewRequest(`GET`, `https://nipuna.lk/users/42?`+test.query, nil)
+			if test.header {
+				req.Header.Set(`X-Request-Id`, uuid.NewString())
+			}
+			_, err := schema.Bind(req)
+			if reflect.TypeOf(err) != reflect.TypeOf(test.err) {
+				t.Errorf(`%v: expected %T, but received %v`, test.query, test.err, err)
+			}
+		}
+	})
+
+	t.Run(`test invalid schemas`, func(t *testing.T) {
+		schemas := [][]SchemaParam{
+			{{Name: `id`, In: InQuery}},
+			{{Name: `id`, In: InQuery, Type: stringType}, {Name: `id`, In: InHeader, Type: stringType}},
+			{{Name: `id`, In: InQuery, Type: intType, Rules: []string{`min=one`}}},
+			{{Name: `id`, In: `body`, Type: intType}},
+		}
+		for _, params := range schemas {
+			_, err := NewParamSchema(params)
+			if _, ok := err.(ErrorUnSupportedParamType); !ok {
+				t.Errorf(`%v: expected "ErrorUnSupportedParamType", but received %v`, params, err)
+			}
+		}
+	})
+}
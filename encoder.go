@@ -0,0 +1,390 @@
+package paramex
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The Encoder interface is implemented to encode a Go struct annotated with param tags into
+// http request headers, url query values, form values and cookies. It is the inverse of an Extractor,
+// therefore values encoded by an Encoder are extracted by an Extractor having the same options
+type Encoder interface {
+	// EncodeHeaders encodes fields of `v` into http headers
+	// `v` should be a Go struct or a Go struct reference
+	EncodeHeaders(v interface{}) (http.Header, error)
+
+	// EncodeQueries encodes fields of `v` into url query values
+	// `v` should be a Go struct or a Go struct reference
+	EncodeQueries(v interface{}) (url.Values, error)
+
+	// EncodeForms encodes fields of `v` into form values
+	// `v` should be a Go struct or a Go struct reference
+	EncodeForms(v interface{}) (url.Values, error)
+
+	// EncodeCookies encodes fields of `v` into cookies
+	// `v` should be a Go struct or a Go struct reference
+	EncodeCookies(v interface{}) ([]*http.Cookie, error)
+}
+
+type encoder struct {
+	opts options
+	// fallback is the location of fields without the in tag option, which are encoded
+	// into every location when it is empty
+	fallback In
+}
+
+// NewParamEncoder returns an Encoder which encodes a Go struct
+// into http headers, url query values, form values and cookies.
+// Field values are formatted by formatters registered with WithFormatter
+func NewParamEncoder(opts ...Option) Encoder {
+	return encoder{opts: newOptions(opts)}
+}
+
+// encodedParam is a key and an encoded value
+type encodedParam struct {
+	key   string
+	value string
+}
+
+// EncodeHeaders encodes fields of v into http headers
+func (e encoder) EncodeHeaders(v interface{}) (http.Header, error) {
+	params, err := e.encode(v, InHeader)
+	if err != nil {
+		return nil, err
+	}
+	header := http.Header{}
+	for _, prm := range params {
+		header.Add(prm.key, prm.value)
+	}
+	return header, nil
+}
+
+// EncodeQueries encodes fields of v into url query values
+func (e encoder) EncodeQueries(v interface{}) (url.Values, error) {
+	return e.encodeValues(v, InQuery)
+}
+
+// EncodeForms encodes fields of v into form values
+func (e encoder) EncodeForms(v interface{}) (url.Values, error) {
+	return e.encodeValues(v, InForm)
+}
+
+// EncodeCookies encodes fields of v into cookies, a slice field is encoded into a cookie for every value
+func (e encoder) EncodeCookies(v interface{}) ([]*http.Cookie, error) {
+	params, err := e.encode(v, InCookie)
+	if err != nil {
+		return nil, err
+	}
+	cookies := make([]*http.Cookie, 0, len(params))
+	for _, prm := range params {
+		cookies = append(cookies, &http.Cookie{Name: prm.key, Value: prm.value})
+	}
+	return cookies, nil
+}
+
+func (e encoder) encodeValues(v interface{}, in In) (url.Values, error) {
+	params, err := e.encode(v, in)
+	if err != nil {
+		return nil, err
+	}
+	values := url.Values{}
+	for _, prm := range params {
+		values.Add(prm.key, prm.value)
+	}
+	return values, nil
+}
+
+// encode encodes fields of v into params of the in location in the field order
+func (e encoder) encode(v interface{}, in In) ([]encodedParam, error) {
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, ErrorNotAssignable{
+				fmt.Errorf(`type of %v is not encodable, required non nil object reference`, value.Type())}
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, ErrorUnSupportedType{
+			fmt.Errorf(`type of %v is not encodable, required struct object`, reflect.TypeOf(v))}
+	}
+
+	var params []encodedParam
+	t := value.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag, ok := e.opts.fieldTag(field)
+		if !ok || !tag.located(in, e.fallback) {
+			continue
+		}
+		if _, omit := tag.option(`omitempty`); omit && empty(value.Field(i)) {
+			continue
+		}
+
+		keys := tag.keys()
+		prm := param{field: field, key: keys[0], keys: keys, in: in, tag: tag}
+		encoded, err := e.encodeField(value.Field(i), prm)
+		if err != nil {
+			return nil, e.opts.withStatus(err)
+		}
+		params = append(params, encoded...)
+	}
+	return params, nil
+}
+
+// empty reports whether value is a zero value, or an empty slice or map
+func empty(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	default:
+		return value.IsZero()
+	}
+}
+
+// encodeField encodes value of prm, which is the inverse of extractor.bind
+func (e encoder) encodeField(value reflect.Value, prm param) ([]encodedParam, error) {
+	if prm.tag.prefix() && value.Kind() == reflect.Map {
+		return e.encodePrefix(value, prm)
+	}
+
+	style, styled, err := e.opts.style(prm)
+	if err != nil {
+		return nil, err
+	}
+	if styled {
+		return e.encodeStyled(value, prm, style)
+	}
+
+	if _, ok := e.opts.formatter(value.Type()); ok {
+		str, err := e.opts.format(prm, value)
+		if err != nil {
+			return nil, err
+		}
+		return []encodedParam{{prm.key, str}}, nil
+	}
+	if value.Kind() == reflect.Slice {
+		if _, ok := e.opts.formatter(value.Type().Elem()); ok {
+			return e.encodeRepeated(value, prm, prm.key)
+		}
+	}
+	if prm.in.keyed() && e.nested(value.Type()) {
+		prm.path = prm.field.Name
+		return e.encodeBrackets(value, prm)
+	}
+	return nil, ErrorUnSupportedParamType{prm.fieldError(
+		fmt.Errorf(`error marshalling %v of "%v", unsupported param type`, value.Type(), prm.key))}
+}
+
+// encodeRepeated encodes every element of the slice value as a value of key
+func (e encoder) encodeRepeated(value reflect.Value, prm param, key string) ([]encodedParam, error) {
+	params := make([]encodedParam, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		str, err := e.opts.format(prm, value.Index(i))
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, encodedParam{key, str})
+	}
+	return params, nil
+}
+
+// encodePrefix encodes entries of a map field as keys having the prefix of prm, e.g. meta.color
+func (e encoder) encodePrefix(value reflect.Value, prm param) ([]encodedParam, error) {
+	prefix := strings.TrimSuffix(prm.key, `*`)
+	var params []encodedParam
+	for _, key := range sortedKeys(value) {
+		elem := value.MapIndex(reflect.ValueOf(key).Convert(value.Type().Key()))
+		prm.key = prefix + key
+		if elem.Kind() == reflect.Slice {
+			encoded, err := e.encodeRepeated(elem, prm, prm.key)
+			if err != nil {
+				return nil, err
+			}
+			params = append(params, encoded...)
+			continue
+		}
+		str, err := e.opts.format(prm, elem)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, encodedParam{prm.key, str})
+	}
+	return params, nil
+}
+
+// encodeStyled encodes value of prm in the style s
+func (e encoder) encodeStyled(value reflect.Value, prm param, s paramStyle) ([]encodedParam, error) {
+	kind, ok := e.opts.kind(value.Type())
+	if !ok {
+		return nil, ErrorUnSupportedParamType{prm.fieldError(
+			fmt.Errorf(`error marshalling %v of "%v", unsupported param type`, value.Type(), prm.key))}
+	}
+	if s.name == styleDeepObject {
+		prm.path = prm.field.Name
+		return e.encodeBrackets(value, prm)
+	}
+
+	values, err := e.styleValues(value, prm, kind)
+	if err != nil {
+		return nil, err
+	}
+	if s.name == styleForm && s.explode && kind == objectKind {
+		params := make([]encodedParam, 0, len(values)/2)
+		for i := 0; i < len(values); i += 2 {
+			params = append(params, encodedParam{values[i], values[i+1]})
+		}
+		return params, nil
+	}
+
+	var params []encodedParam
+	for _, raw := range s.encode(prm.key, values, kind) {
+		params = append(params, encodedParam{prm.key, raw})
+	}
+	return params, nil
+}
+
+// styleValues returns the formatted value of a primitive, formatted elements of an array or
+// alternating names and formatted values of object properties
+func (e encoder) styleValues(value reflect.Value, prm param, kind valueKind) ([]string, error) {
+	switch {
+	case kind == primitiveKind:
+		str, err := e.opts.format(prm, value)
+		return []string{str}, err
+	case kind == arrayKind:
+		encoded, err := e.encodeRepeated(value, prm, prm.key)
+		values := make([]string, 0, len(encoded))
+		for _, p := range encoded {
+			values = append(values, p.value)
+		}
+		return values, err
+	case value.Kind() == reflect.Map:
+		var values []string
+		for _, key := range sortedKeys(value) {
+			str, err := e.opts.format(prm, value.MapIndex(reflect.ValueOf(key).Convert(value.Type().Key())))
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, key, str)
+		}
+		return values, nil
+	default:
+		var values []string
+		t := value.Type()
+		for i := 0; i < t.NumField(); i++ {
+			tag, ok := e.opts.fieldTag(t.Field(i))
+			if !ok {
+				continue
+			}
+			field := value.Field(i)
+			if _, omit := tag.option(`omitempty`); omit && empty(field) {
+				continue
+			}
+			nested := param{field: t.Field(i), path: prm.field.Name + `.` + t.Field(i).Name,
+				key: tag.keys()[0], keys: tag.keys(), in: prm.in, tag: tag}
+			str, err := e.opts.format(nested, field)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, nested.key, str)
+		}
+		return values, nil
+	}
+}
+
+// nested reports whether values of type t are encoded into bracket notation keys
+func (e encoder) nested(t reflect.Type) bool {
+	if _, ok := e.opts.formatter(t); ok {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map:
+		return true
+	case reflect.Slice:
+		return e.nested(t.Elem())
+	default:
+		return false
+	}
+}
+
+// encodeBrackets encodes nested structs, maps and slices into bracket notation keys,
+// e.g. filter[owner][id]=5 and items[0][sku]=a. It is the inverse of extractor.bindBrackets
+func (e encoder) encodeBrackets(value reflect.Value, prm param) ([]encodedParam, error) {
+	if _, ok := e.opts.formatter(value.Type()); ok {
+		str, err := e.opts.format(prm, value)
+		if err != nil {
+			return nil, err
+		}
+		return []encodedParam{{prm.key, str}}, nil
+	}
+
+	var params []encodedParam
+	switch value.Kind() {
+	case reflect.Struct:
+		t := value.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			tag, ok := e.opts.fieldTag(field)
+			if !ok {
+				continue
+			}
+			if _, omit := tag.option(`omitempty`); omit && empty(value.Field(i)) {
+				continue
+			}
+			nested := param{field: field, path: prm.path + `.` + field.Name,
+				key: prm.key + `[` + tag.keys()[0] + `]`, keys: tag.keys(), in: prm.in, tag: tag}
+			encoded, err := e.encodeBrackets(value.Field(i), nested)
+			if err != nil {
+				return nil, err
+			}
+			params = append(params, encoded...)
+		}
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return nil, ErrorUnSupportedParamType{prm.fieldError(
+				fmt.Errorf(`error marshalling %v of "%v", map key should be a string`, value.Type(), prm.key))}
+		}
+		key := prm.key
+		for _, k := range sortedKeys(value) {
+			prm.key = key + `[` + k + `]`
+			encoded, err := e.encodeBrackets(value.MapIndex(reflect.ValueOf(k).Convert(value.Type().Key())), prm)
+			if err != nil {
+				return nil, err
+			}
+			params = append(params, encoded...)
+		}
+	case reflect.Slice:
+		if _, ok := e.opts.formatter(value.Type().Elem()); ok {
+			return e.encodeRepeated(value, prm, prm.key+`[]`)
+		}
+		key := prm.key
+		for i := 0; i < value.Len(); i++ {
+			prm.key = key + `[` + strconv.Itoa(i) + `]`
+			encoded, err := e.encodeBrackets(value.Index(i), prm)
+			if err != nil {
+				return nil, err
+			}
+			params = append(params, encoded...)
+		}
+	default:
+		return nil, ErrorUnSupportedParamType{prm.fieldError(
+			fmt.Errorf(`error marshalling %v of "%v", unsupported param type`, value.Type(), prm.key))}
+	}
+	return params, nil
+}
+
+// sortedKeys returns keys of the string keyed map value in sorted order
+func sortedKeys(value reflect.Value) []string {
+	keys := make([]string, 0, value.Len())
+	for _, key := range value.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,293 @@
+package paramex
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type encodedOwner struct {
+	ID   int    `param:"id"`
+	Name string `param:"name"`
+}
+
+type encodedFilter struct {
+	Status string       `param:"status"`
+	Owner  encodedOwner `param:"owner"`
+	Tags   []string     `param:"tags"`
+}
+
+type encodedParams struct {
+	Name    string         `param:"name"`
+	Age     int            `param:"age"`
+	Count   int32          `param:"count"`
+	Total   int64          `param:"total"`
+	Height  float32        `param:"height"`
+	Score   float64        `param:"score"`
+	Married bool           `param:"married"`
+	ID      uuid.UUID      `param:"id"`
+	Names   []string       `param:"names"`
+	Sizes   []int          `param:"sizes,style=pipeDelimited"`
+	Filter  encodedFilter  `param:"filter"`
+	Items   []encodedOwner `param:"items"`
+}
+
+type encodedHeaders struct {
+	Name    string    `param:"X-Name"`
+	Age     int       `param:"X-Age"`
+	Score   float64   `param:"X-Score"`
+	Married bool      `param:"X-Married"`
+	ID      uuid.UUID `param:"X-Request-Id"`
+	Sizes   []int64   `param:"X-Sizes"`
+}
+
+// normalized replaces empty slices of v by nil slices, since absent parameters are not bound
+func normalized(v interface{}) interface{} {
+	value := reflect.New(reflect.TypeOf(v)).Elem()
+	value.Set(reflect.ValueOf(v))
+	normalize(value)
+	return value.Interface()
+}
+
+func normalize(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			normalize(value.Field(i))
+		}
+	case reflect.Slice:
+		if value.Len() == 0 {
+			value.Set(reflect.Zero(value.Type()))
+		}
+		for i := 0; i < value.Len(); i++ {
+			normalize(value.Index(i))
+		}
+	}
+}
+
+func TestEncoder_RoundTrip(t *testing.T) {
+	encoder := NewParamEncoder()
+	extractor := NewParamExtractor()
+
+	t.Run(`test queries`, func(t *testing.T) {
+		property := func(x encodedParams) bool {
+			values, err := encoder.EncodeQueries(x)
+			if err != nil {
+				t.Log(err)
+				return false
+			}
+			req, _ := http.NewRequest(`GET`, `https://nipuna.lk?`+values.Encode(), nil)
+			decoded := encodedParams{}
+			err = extractor.ExtractQueries(&decoded, req)
+			if err != nil {
+				t.Log(err)
+				return false
+			}
+			return reflect.DeepEqual(normalized(x), normalized(decoded))
+		}
+		err := quick.Check(property, nil)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run(`test forms`, func(t *testing.T) {
+		property := func(x encodedParams) bool {
+			values, err := encoder.EncodeForms(&x)
+			if err != nil {
+				t.Log(err)
+				return false
+			}
+			req, _ := http.NewRequest(`POST`, `https://nipuna.lk`, strings.NewReader(values.Encode()))
+			req.Header.Set(`Content-Type`, `application/x-www-form-urlencoded`)
+			decoded := encodedParams{}
+			err = extractor.ExtractForms(&decoded, req)
+			if err != nil {
+				t.Log(err)
+				return false
+			}
+			return reflect.DeepEqual(normalized(x), normalized(decoded))
+		}
+		err := quick.Check(property, nil)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run(`test headers`, func(t *testing.T) {
+		property := func(x encodedHeaders) bool {
+			header, err := encoder.EncodeHeaders(x)
+			if err != nil {
+				t.Log(err)
+				return false
+			}
+			req, _ := http.NewRequest(`GET`, `https://nipuna.lk`, nil)
+			req.Header = header
+			decoded := encodedHeaders{}
+			err = extractor.ExtractHeaders(&decoded, req)
+			if err != nil {
+				t.Log(err)
+				return false
+			}
+			return reflect.DeepEqual(normalized(x), normalized(decoded))
+		}
+		err := quick.Check(property, nil)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run(`test cookies`, func(t *testing.T) {
+		x := struct {
+			Session string  `param:"session"`
+			Seen    []int   `param:"seen"`
+			Colors  []int   `param:"colors,style=form,explode=false"`
+			Ratio   float64 `param:"ratio"`
+		}{`abc`, []int{1, 2}, []int{3, 4}, 0.25}
+		cookies, err := encoder.EncodeCookies(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, _ := http.NewRequest(`GET`, `https://nipuna.lk`, nil)
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
+		decoded := x
+		decoded.Session, decoded.Seen, decoded.Colors, decoded.Ratio = ``, nil, nil, 0
+		err = extractor.ExtractCookies(&decoded, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(x, decoded) {
+			t.Errorf(`expected %+v, but received %+v`, x, decoded)
+		}
+	})
+}
+
+func TestEncoder_Styles(t *testing.T) {
+	tests := []struct {
+		tag      string
+		in       In
+		raw      string
+		expected interface{}
+	}{
+		{`color,style=matrix`, InPath, `;color`, ``},
+		{`color,style=matrix`, InPath, `;color=blue`, stylePrimitive},
+		{`color,style=matrix`, InPath, `;color=blue,black,brown`, styleArray},
+		{`color,style=matrix`, InPath, `;color=R,100,G,200,B,150`, styleObject},
+		{`color,style=matrix,explode`, InPath, `;color=blue;color=black;color=brown`, styleArray},
+		{`color,style=matrix,explode`, InPath, `;R=100;G=200;B=150`, styleObject},
+		{`color,style=label`, InPath, `.blue.black.brown`, styleArray},
+		{`color,style=label,explode`, InPath, `.R=100.G=200.B=150`, styleObject},
+		{`color,style=simple`, InPath, `R,100,G,200,B,150`, styleObject},
+		{`color,style=simple,explode`, InPath, `B=150,G=200,R=100`, styleMap},
+		{`color`, InHeader, `blue,black,brown`, styleArray},
+		{`color,style=form,explode=false`, InQuery, `color=blue%2Cblack%2Cbrown`, styleArray},
+		{`color,style=form`, InQuery, `B=150&G=200&R=100`, styleMap},
+		{`color,style=spaceDelimited`, InQuery, `color=blue+black+brown`, styleArray},
+		{`color,style=deepObject`, InQuery, `color%5BB%5D=150&color%5BG%5D=200&color%5BR%5D=100`, styleObject},
+	}
+
+	e := NewParamEncoder().(encoder)
+	for _, test := range tests {
+		v := reflect.New(reflect.TypeOf(styledStruct(test.expected, test.tag)).Elem())
+		v.Elem().Field(0).Set(reflect.ValueOf(test.expected))
+		params, err := e.encode(v.Interface(), test.in)
+		if err != nil {
+			t.Fatalf(`%v: unexpected error %v`, test.tag, err)
+		}
+
+		values := url.Values{}
+		for _, prm := range params {
+			values.Add(prm.key, prm.value)
+		}
+		received := values.Encode()
+		if test.in == InPath || test.in == InHeader {
+			received = values.Get(`color`)
+		}
+		if received != test.raw {
+			t.Errorf(`%v: expected %v, but received %v`, test.tag, test.raw, received)
+		}
+	}
+}
+
+func TestEncoder_Options(t *testing.T) {
+	t.Run(`test omitempty`, func(t *testing.T) {
+		obj := struct {
+			Name  string            `param:"name,omitempty"`
+			Age   int               `param:"age,omitempty"`
+			Tags  []string          `param:"tags,omitempty"`
+			Meta  map[string]string `param:"meta.*"`
+			Count int               `param:"count"`
+		}{Meta: map[string]string{`b`: `2`, `a`: `1`}}
+		values, err := NewParamEncoder().EncodeQueries(obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if encoded := values.Encode(); encoded != `count=0&meta.a=1&meta.b=2` {
+			t.Errorf(`unexpected encoded values %v`, encoded)
+		}
+	})
+
+	t.Run(`test formatters`, func(t *testing.T) {
+		date := time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)
+		obj := struct {
+			From time.Time `param:"from"`
+			To   time.Time `param:"to"`
+		}{date, date}
+		encoder := NewParamEncoder(
+			WithConverter(time.Time{}, func(value string) (interface{}, error) {
+				return time.Parse(time.RFC3339, value)
+			}),
+			WithFormatter(``, func(value interface{}) (string, error) {
+				return strings.ToUpper(value.(string)), nil
+			}),
+		)
+		values, err := encoder.EncodeQueries(obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if values.Get(`from`) != `2021-05-01T00:00:00Z` {
+			t.Errorf(`unexpected formatted time %v`, values.Get(`from`))
+		}
+
+		_, err = NewParamEncoder().EncodeQueries(struct {
+			Value complex128 `param:"value"`
+		}{})
+		if _, ok := err.(ErrorUnSupportedParamType); !ok {
+			t.Errorf(`expected "ErrorUnSupportedParamType", but received %v`, err)
+		}
+
+		failing := NewParamEncoder(WithFormatter(time.Time{}, func(value interface{}) (string, error) {
+			return ``, errors.New(`invalid time`)
+		}))
+		_, err = failing.EncodeQueries(obj)
+		var fieldErr *FieldError
+		if _, ok := err.(ErrorMarshalType); !ok || !errors.As(err, &fieldErr) || fieldErr.Field != `From` {
+			t.Errorf(`expected "ErrorMarshalType" of From, but received %v`, err)
+		}
+	})
+
+	t.Run(`test invalid values`, func(t *testing.T) {
+		encoder := NewParamEncoder()
+		_, err := encoder.EncodeHeaders((*encodedHeaders)(nil))
+		if _, ok := err.(ErrorNotAssignable); !ok {
+			t.Errorf(`expected "ErrorNotAssignable", but received %v`, err)
+		}
+		_, err = encoder.EncodeHeaders(5)
+		if _, ok := err.(ErrorUnSupportedType); !ok {
+			t.Errorf(`expected "ErrorUnSupportedType", but received %v`, err)
+		}
+		_, err = encoder.EncodeHeaders(encodedParams{})
+		if _, ok := err.(ErrorUnSupportedParamType); !ok {
+			t.Errorf(`expected "ErrorUnSupportedParamType", but received %v`, err)
+		}
+	})
+}
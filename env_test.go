@@ -0,0 +1,64 @@
+package paramex
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type envConfig struct {
+	Port    int           `param:"PORT,default=8080,max=65535"`
+	Hosts   []string      `param:"HOSTS"`
+	Debug   bool          `param:"DEBUG"`
+	Secret  string        `param:"SECRET,required"`
+	Timeout time.Duration `param:"TIMEOUT"`
+	Trace   string        `param:"X-Trace-Id,in=header"`
+}
+
+func TestExtractor_ExtractEnv(t *testing.T) {
+	extractor := NewParamExtractor(WithEnvPrefix(`PARAMEX_`), WithConverter(time.Duration(0),
+		func(value string) (interface{}, error) {
+			return time.ParseDuration(value)
+		}))
+
+	t.Run(`test env`, func(t *testing.T) {
+		t.Setenv(`PARAMEX_HOSTS`, `a.nipuna.lk, b.nipuna.lk`)
+		t.Setenv(`PARAMEX_DEBUG`, `true`)
+		t.Setenv(`PARAMEX_SECRET`, `s3cret`)
+		t.Setenv(`PARAMEX_TIMEOUT`, `5s`)
+		t.Setenv(`PARAMEX_PORT`, ``)
+		t.Setenv(`X-Trace-Id`, `ignored`)
+
+		config := envConfig{}
+		err := extractor.ExtractEnv(&config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := envConfig{Port: 8080, Hosts: []string{`a.nipuna.lk`, `b.nipuna.lk`}, Debug: true,
+			Secret: `s3cret`, Timeout: 5 * time.Second}
+		if !reflect.DeepEqual(config, expected) {
+			t.Errorf(`expected %v, but received %v`, expected, config)
+		}
+	})
+
+	t.Run(`test errors`, func(t *testing.T) {
+		config := envConfig{}
+		err := extractor.ExtractEnv(&config)
+		if _, ok := err.(ErrorRequiredParam); !ok {
+			t.Errorf(`expected "ErrorRequiredParam", but received %v`, err)
+		}
+
+		t.Setenv(`PARAMEX_SECRET`, `s3cret`)
+		t.Setenv(`PARAMEX_PORT`, `70000`)
+		err = extractor.ExtractEnv(&config)
+		if _, ok := err.(ErrorInvalidValue); !ok {
+			t.Errorf(`expected "ErrorInvalidValue", but received %v`, err)
+		}
+
+		t.Setenv(`PARAMEX_PORT`, `http`)
+		err = extractor.ExtractEnv(&config)
+		if _, ok := err.(ErrorUnmarshalType); !ok {
+			t.Errorf(`expected "ErrorUnmarshalType", but received %v`, err)
+		}
+	})
+}
@@ -1,5 +1,17 @@
 package paramex
 
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// StatusCoder is implemented by every paramex error to report the http status code
+// of a response to a request which failed binding
+type StatusCoder interface {
+	StatusCode() int
+}
+
 // ErrorUnSupportedParamType created when trying to extract unsupported parameter type
 type ErrorUnSupportedParamType struct {
 	error
@@ -19,3 +31,358 @@ type ErrorNotAssignable struct {
 type ErrorUnSupportedType struct {
 	error
 }
+
+// ErrorMalformedRequest created when the request body or url query can not be parsed
+type ErrorMalformedRequest struct {
+	error
+}
+
+// ErrorBodyTooLarge created when the request body exceeds the allowed size
+type ErrorBodyTooLarge struct {
+	error
+}
+
+// ErrorUnsupportedMediaType created when the request content type can not be decoded
+type ErrorUnsupportedMediaType struct {
+	error
+}
+
+// ErrorUnknownParams created in strict mode when the request has parameters not declared by any field
+type ErrorUnknownParams struct {
+	error
+	// In is the location of the parameters
+	In In
+	// Keys are the undeclared parameter keys
+	Keys []string
+}
+
+// ErrorMultipleValues created when a non slice field receives multiple values
+// while RejectMultipleValues policy is set
+type ErrorMultipleValues struct {
+	error
+	// In is the location of the parameter
+	In In
+	// Key is the parameter key
+	Key string
+	// Values are all conflicting values
+	Values []string
+}
+
+// ErrorAmbiguousKey created when several request keys match a single field after key normalization
+type ErrorAmbiguousKey struct {
+	error
+	// In is the location of the parameter
+	In In
+	// Key is the parameter key
+	Key string
+	// Keys are the matching request keys
+	Keys []string
+}
+
+// ErrorLimitExceeded created when a bracket notation key exceeds the depth or index limits
+type ErrorLimitExceeded struct {
+	error
+}
+
+// ErrorMarshalType created when a formatter fails to encode a field value
+type ErrorMarshalType struct {
+	error
+}
+
+// ErrorRequiredParam created when a parameter of a field having the required tag option is absent
+type ErrorRequiredParam struct {
+	error
+}
+
+// ErrorInvalidValue created when a parameter value violates a constraint of the field tag,
+// such as the enum, min and max tag options
+type ErrorInvalidValue struct {
+	error
+}
+
+// ErrorUnknownOperation created when a request does not match any operation of an OpenAPI document
+type ErrorUnknownOperation struct {
+	error
+	// Allowed are the methods of the operations of the request path, which is empty when no path matches
+	Allowed []string
+}
+
+// StatusCode returns 500 by default, since unsupported field types are programmer errors
+func (e ErrorUnSupportedParamType) StatusCode() int {
+	return overriddenStatus(e.error, http.StatusInternalServerError)
+}
+
+// StatusCode returns 400 by default
+func (e ErrorUnmarshalType) StatusCode() int {
+	return overriddenStatus(e.error, http.StatusBadRequest)
+}
+
+// StatusCode returns 500 by default, since extracting into a non reference is a programmer error
+func (e ErrorNotAssignable) StatusCode() int {
+	return overriddenStatus(e.error, http.StatusInternalServerError)
+}
+
+// StatusCode returns 500 by default, since extracting into a non struct is a programmer error
+func (e ErrorUnSupportedType) StatusCode() int {
+	return overriddenStatus(e.error, http.StatusInternalServerError)
+}
+
+// StatusCode returns 400 by default
+func (e ErrorMalformedRequest) StatusCode() int {
+	return overriddenStatus(e.error, http.StatusBadRequest)
+}
+
+// StatusCode returns 413 by default
+func (e ErrorBodyTooLarge) StatusCode() int {
+	return overriddenStatus(e.error, http.StatusRequestEntityTooLarge)
+}
+
+// StatusCode returns 415 by default
+func (e ErrorUnsupportedMediaType) StatusCode() int {
+	return overriddenStatus(e.error, http.StatusUnsupportedMediaType)
+}
+
+// StatusCode returns 400 by default
+func (e ErrorUnknownParams) StatusCode() int {
+	return overriddenStatus(e.error, http.StatusBadRequest)
+}
+
+// StatusCode returns 400 by default
+func (e ErrorMultipleValues) StatusCode() int {
+	return overriddenStatus(e.error, http.StatusBadRequest)
+}
+
+// StatusCode returns 400 by default
+func (e ErrorAmbiguousKey) StatusCode() int {
+	return overriddenStatus(e.error, http.StatusBadRequest)
+}
+
+// StatusCode returns 400 by default
+func (e ErrorLimitExceeded) StatusCode() int {
+	return overriddenStatus(e.error, http.StatusBadRequest)
+}
+
+// StatusCode returns 500 by default, since encoding fails on the side of the client
+func (e ErrorMarshalType) StatusCode() int {
+	return overriddenStatus(e.error, http.StatusInternalServerError)
+}
+
+// StatusCode returns 400 by default
+func (e ErrorRequiredParam) StatusCode() int {
+	return overriddenStatus(e.error, http.StatusBadRequest)
+}
+
+// StatusCode returns 400 by default
+func (e ErrorInvalidValue) StatusCode() int {
+	return overriddenStatus(e.error, http.StatusBadRequest)
+}
+
+// StatusCode returns 405 by default when the request path matches operations of other methods, otherwise 404
+func (e ErrorUnknownOperation) StatusCode() int {
+	if len(e.Allowed) > 0 {
+		return overriddenStatus(e.error, http.StatusMethodNotAllowed)
+	}
+	return overriddenStatus(e.error, http.StatusNotFound)
+}
+
+// Unwrap returns the underlying error, which wraps a *FieldError when the error is caused by a parameter
+func (e ErrorUnSupportedParamType) Unwrap() error { return e.error }
+
+// Unwrap returns the underlying error, which wraps a *FieldError when the error is caused by a parameter
+func (e ErrorUnmarshalType) Unwrap() error { return e.error }
+
+// Unwrap returns the underlying error
+func (e ErrorNotAssignable) Unwrap() error { return e.error }
+
+// Unwrap returns the underlying error
+func (e ErrorUnSupportedType) Unwrap() error { return e.error }
+
+// Unwrap returns the underlying error
+func (e ErrorMalformedRequest) Unwrap() error { return e.error }
+
+// Unwrap returns the underlying error
+func (e ErrorBodyTooLarge) Unwrap() error { return e.error }
+
+// Unwrap returns the underlying error
+func (e ErrorUnsupportedMediaType) Unwrap() error { return e.error }
+
+// Unwrap returns the underlying error
+func (e ErrorUnknownParams) Unwrap() error { return e.error }
+
+// Unwrap returns the underlying error, which wraps a *FieldError
+func (e ErrorMultipleValues) Unwrap() error { return e.error }
+
+// Unwrap returns the underlying error, which wraps a *FieldError
+func (e ErrorAmbiguousKey) Unwrap() error { return e.error }
+
+// Unwrap returns the underlying error, which wraps a *FieldError
+func (e ErrorLimitExceeded) Unwrap() error { return e.error }
+
+// Unwrap returns the underlying error, which wraps a *FieldError
+func (e ErrorMarshalType) Unwrap() error { return e.error }
+
+// Unwrap returns the underlying error, which wraps a *FieldError
+func (e ErrorRequiredParam) Unwrap() error { return e.error }
+
+// Unwrap returns the underlying error, which wraps a *FieldError
+func (e ErrorInvalidValue) Unwrap() error { return e.error }
+
+// Unwrap returns the underlying error
+func (e ErrorUnknownOperation) Unwrap() error { return e.error }
+
+func (e ErrorUnSupportedParamType) withStatus(code int) error {
+	e.error = statusError{e.error, code}
+	return e
+}
+
+func (e ErrorUnmarshalType) withStatus(code int) error {
+	e.error = statusError{e.error, code}
+	return e
+}
+
+func (e ErrorNotAssignable) withStatus(code int) error {
+	e.error = statusError{e.error, code}
+	return e
+}
+
+func (e ErrorUnSupportedType) withStatus(code int) error {
+	e.error = statusError{e.error, code}
+	return e
+}
+
+func (e ErrorMalformedRequest) withStatus(code int) error {
+	e.error = statusError{e.error, code}
+	return e
+}
+
+func (e ErrorBodyTooLarge) withStatus(code int) error {
+	e.error = statusError{e.error, code}
+	return e
+}
+
+func (e ErrorUnsupportedMediaType) withStatus(code int) error {
+	e.error = statusError{e.error, code}
+	return e
+}
+
+func (e ErrorUnknownParams) withStatus(code int) error {
+	e.error = statusError{e.error, code}
+	return e
+}
+
+func (e ErrorMultipleValues) withStatus(code int) error {
+	e.error = statusError{e.error, code}
+	return e
+}
+
+func (e ErrorAmbiguousKey) withStatus(code int) error {
+	e.error = statusError{e.error, code}
+	return e
+}
+
+func (e ErrorLimitExceeded) withStatus(code int) error {
+	e.error = statusError{e.error, code}
+	return e
+}
+
+func (e ErrorMarshalType) withStatus(code int) error {
+	e.error = statusError{e.error, code}
+	return e
+}
+
+func (e ErrorRequiredParam) withStatus(code int) error {
+	e.error = statusError{e.error, code}
+	return e
+}
+
+func (e ErrorInvalidValue) withStatus(code int) error {
+	e.error = statusError{e.error, code}
+	return e
+}
+
+func (e ErrorUnknownOperation) withStatus(code int) error {
+	e.error = statusError{e.error, code}
+	return e
+}
+
+// statusOverrider is implemented by paramex errors to replace their default status code
+type statusOverrider interface {
+	withStatus(code int) error
+}
+
+// statusError carries a status code configured with WithStatusCode
+type statusError struct {
+	error
+	code int
+}
+
+func (e statusError) Unwrap() error { return e.error }
+
+func overriddenStatus(err error, code int) int {
+	if s, ok := err.(statusError); ok {
+		return s.code
+	}
+	return code
+}
+
+// FieldError describes the parameter which caused an error. paramex errors caused by a
+// parameter wrap a *FieldError, which can be retrieved using errors.As
+type FieldError struct {
+	// Field is the name of the Go struct field
+	Field string
+	// Name is the parameter key
+	Name string
+	// In is the location of the parameter
+	In In
+	// Err is the reason of the failure
+	Err error
+}
+
+func (e *FieldError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the reason of the failure
+func (e *FieldError) Unwrap() error { return e.Err }
+
+// Errors is a collection of errors, such as errors of several parameters
+type Errors []error
+
+func (e Errors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, `; `)
+}
+
+// Unwrap returns the errors of the collection, so errors.Is and errors.As inspect every error
+func (e Errors) Unwrap() []error { return e }
+
+// StatusCode returns the status code shared by all errors. A server error
+// status code is returned if any error is a server error, otherwise 400
+func (e Errors) StatusCode() int {
+	status := 0
+	for _, err := range e {
+		s := statusCode(err)
+		switch {
+		case s >= http.StatusInternalServerError:
+			return s
+		case status == 0:
+			status = s
+		case status != s:
+			status = http.StatusBadRequest
+		}
+	}
+	if status == 0 {
+		return http.StatusBadRequest
+	}
+	return status
+}
+
+// statusCode returns the status code of a StatusCoder and 500 for every other error
+func statusCode(err error) int {
+	var coder StatusCoder
+	if errors.As(err, &coder) {
+		return coder.StatusCode()
+	}
+	return http.StatusInternalServerError
+}
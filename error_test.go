@@ -2,6 +2,7 @@ package paramex
 
 import (
 	"errors"
+	"net/http"
 	"testing"
 )
 
@@ -42,3 +43,88 @@ func Test_Errors(t *testing.T) {
 		}
 	})
 }
+
+func Test_FieldErrors(t *testing.T) {
+	t.Run(`test_FieldError`, func(t *testing.T) {
+		err := ErrorUnmarshalType{&FieldError{Name: `age`, In: InQuery, Err: errors.New(`test error`)}}
+		if err.Error() != `test error` {
+			t.Errorf(`expexted "test error", received "%v"`, err.Error())
+		}
+		fieldErr := &FieldError{}
+		if !errors.As(err, &fieldErr) || fieldErr.Name != `age` {
+			t.Errorf(`expexted field error of "age", received "%v"`, fieldErr)
+		}
+	})
+
+	t.Run(`test_Errors`, func(t *testing.T) {
+		err := Errors{errors.New(`test error`), errors.New(`other error`)}
+		if err.Error() != `test error; other error` {
+			t.Errorf(`expexted "test error; other error", received "%v"`, err.Error())
+		}
+	})
+
+	t.Run(`test_Errors_As`, func(t *testing.T) {
+		type params struct {
+			Name string `param:"name,required"`
+			Age  int    `param:"age"`
+		}
+		req, _ := http.NewRequest(http.MethodGet, `https://nipuna.lk?age=old`, nil)
+		err := NewParamExtractor(WithErrorAggregation()).ExtractQueries(&params{}, req)
+		if !errors.As(err, &ErrorRequiredParam{}) {
+			t.Errorf(`expexted "ErrorRequiredParam", received "%v"`, err)
+		}
+		unmarshalErr := ErrorUnmarshalType{}
+		fieldErr := &FieldError{}
+		if !errors.As(err, &unmarshalErr) || !errors.As(unmarshalErr, &fieldErr) || fieldErr.Name != `age` {
+			t.Errorf(`expexted field error of "age", received "%v"`, err)
+		}
+	})
+}
+
+func Test_ErrorStatusCodes(t *testing.T) {
+	tests := []struct {
+		err    StatusCoder
+		status int
+	}{
+		{ErrorUnSupportedParamType{errors.New(`test error`)}, http.StatusInternalServerError},
+		{ErrorUnmarshalType{errors.New(`test error`)}, http.StatusBadRequest},
+		{ErrorNotAssignable{errors.New(`test error`)}, http.StatusInternalServerError},
+		{ErrorUnSupportedType{errors.New(`test error`)}, http.StatusInternalServerError},
+		{ErrorMalformedRequest{errors.New(`test error`)}, http.StatusBadRequest},
+		{ErrorBodyTooLarge{errors.New(`test error`)}, http.StatusRequestEntityTooLarge},
+		{ErrorUnsupportedMediaType{errors.New(`test error`)}, http.StatusUnsupportedMediaType},
+		{Errors{ErrorUnmarshalType{errors.New(`test error`)}, ErrorBodyTooLarge{errors.New(`test error`)}}, http.StatusBadRequest},
+		{Errors{ErrorUnmarshalType{errors.New(`test error`)}, ErrorNotAssignable{errors.New(`test error`)}}, http.StatusInternalServerError},
+	}
+	for _, test := range tests {
+		if test.err.StatusCode() != test.status {
+			t.Errorf(`expected [%d] for %T, but received [%d]`, test.status, test.err, test.err.StatusCode())
+		}
+	}
+
+	t.Run(`test_WithStatusCode`, func(t *testing.T) {
+		opts := newOptions([]Option{WithStatusCode(ErrorUnmarshalType{}, http.StatusUnprocessableEntity)})
+		err := opts.withStatus(Errors{
+			ErrorUnmarshalType{&FieldError{Name: `age`, Err: errors.New(`test error`)}},
+			ErrorBodyTooLarge{errors.New(`test error`)},
+		}).(Errors)
+
+		unmarshalErr, ok := err[0].(ErrorUnmarshalType)
+		if !ok {
+			t.Fatalf(`expected "ErrorUnmarshalType", but received %T`, err[0])
+		}
+		if unmarshalErr.StatusCode() != http.StatusUnprocessableEntity {
+			t.Errorf(`expected [%d], but received [%d]`, http.StatusUnprocessableEntity, unmarshalErr.StatusCode())
+		}
+		if unmarshalErr.Error() != `test error` {
+			t.Errorf(`expexted "test error", received "%v"`, unmarshalErr.Error())
+		}
+		fieldErr := &FieldError{}
+		if !errors.As(unmarshalErr, &fieldErr) || fieldErr.Name != `age` {
+			t.Errorf(`expexted field error of "age", received "%v"`, fieldErr)
+		}
+		if err[1].(StatusCoder).StatusCode() != http.StatusRequestEntityTooLarge {
+			t.Errorf(`expected [%d], but received [%d]`, http.StatusRequestEntityTooLarge, err[1].(StatusCoder).StatusCode())
+		}
+	})
+}
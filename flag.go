@@ -0,0 +1,110 @@
+package paramex
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FlagBinding binds command line flags registered by RegisterFlags into a Go struct
+type FlagBinding struct {
+	v      interface{}
+	p      extractor
+	values flagSource
+}
+
+// RegisterFlags registers a flag onto fs for every key of fields of v, which should be a Go struct reference.
+// Fields located in other locations by the in tag option and fields capturing keys by a prefix are skipped.
+// Usages of flags are set by the usage tag option, e.g. `param:"port|p,default=8080,usage=listening port"`.
+//
+// Flags are bound by FlagBinding.Bind after parsing fs. Slices are bound from repeated flags, and the last
+// value of a repeated non slice flag wins, same as the flag package, unless set by WithMultipleValues or
+// the multi tag option
+func RegisterFlags(fs *flag.FlagSet, v interface{}, opts ...Option) (*FlagBinding, error) {
+	opts = append([]Option{WithMultipleValues(LastValue)}, opts...)
+	b := &FlagBinding{v: v, p: extractor{opts: newOptions(opts)}, values: flagSource{}}
+	t := reflect.TypeOf(v)
+	if v == nil || t.Kind() != reflect.Ptr {
+		return nil, ErrorNotAssignable{
+			fmt.Errorf(`type of %v is not assignabale, required object reference`, t)}
+	}
+	t = t.Elem()
+	if t.Kind() != reflect.Struct {
+		return nil, ErrorUnSupportedType{
+			fmt.Errorf(`type of %v is not extractable, required struct object`, t.String())}
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := b.p.opts.fieldTag(field)
+		if !ok || !tag.located(InFlag, ``) || tag.prefix() && field.Type.Kind() == reflect.Map {
+			continue
+		}
+
+		value := &flagValue{boolean: field.Type.Kind() == reflect.Bool}
+		value.defaultValue, _ = tag.option(`default`)
+		usage, _ := tag.option(`usage`)
+		for _, key := range tag.keys() {
+			b.values[key] = value
+			fs.Var(value, key, usage)
+		}
+	}
+	return b, nil
+}
+
+// Bind binds flags set by the parsed command line, using the same converters, defaults and constraints as
+// an Extractor. Absent required flags fail with ErrorRequiredParam
+func (b *FlagBinding) Bind() error {
+	_, err := b.p.BindFrom(b.v, b.values, InFlag)
+	return err
+}
+
+// flagValue is a flag.Value collecting every value of a flag
+type flagValue struct {
+	values       []string
+	defaultValue string
+	boolean      bool
+}
+
+// String returns the set values, or the default value shown by flag.PrintDefaults
+func (f *flagValue) String() string {
+	if f == nil {
+		return ``
+	}
+	if len(f.values) == 0 {
+		return f.defaultValue
+	}
+	return strings.Join(f.values, `,`)
+}
+
+func (f *flagValue) Set(value string) error {
+	f.values = append(f.values, value)
+	return nil
+}
+
+// IsBoolFlag allows boolean flags without values, e.g. -verbose
+func (f *flagValue) IsBoolFlag() bool {
+	return f.boolean
+}
+
+// flagSource looks up values of set flags by their names
+type flagSource map[string]*flagValue
+
+func (s flagSource) Lookup(key string) ([]string, bool) {
+	value, ok := s[key]
+	if !ok || len(value.values) == 0 {
+		return nil, false
+	}
+	return value.values, true
+}
+
+func (s flagSource) Keys() []string {
+	keys := make([]string, 0, len(s))
+	for key, value := range s {
+		if len(value.values) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
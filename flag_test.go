@@ -0,0 +1,107 @@
+package paramex
+
+import (
+	"flag"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type flagConfig struct {
+	Port    int      `param:"port|p,default=8080,usage=listening port"`
+	Hosts   []string `param:"host"`
+	Verbose bool     `param:"verbose"`
+	Mode    string   `param:"mode,required,enum=dev|prod"`
+	Trace   string   `param:"X-Trace-Id,in=header"`
+}
+
+func TestRegisterFlags(t *testing.T) {
+	parse := func(args ...string) (flagConfig, error) {
+		fs := flag.NewFlagSet(`paramex`, flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		config := flagConfig{}
+		binding, err := RegisterFlags(fs, &config)
+		if err != nil {
+			return config, err
+		}
+		err = fs.Parse(args)
+		if err != nil {
+			return config, err
+		}
+		return config, binding.Bind()
+	}
+
+	t.Run(`test flags`, func(t *testing.T) {
+		config, err := parse(`-p`, `9090`, `-host`, `a`, `-host`, `b`, `-verbose`, `-mode=dev`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := flagConfig{Port: 9090, Hosts: []string{`a`, `b`}, Verbose: true, Mode: `dev`}
+		if !reflect.DeepEqual(config, expected) {
+			t.Errorf(`expected %v, but received %v`, expected, config)
+		}
+
+		config, err = parse(`-mode`, `prod`)
+		if err != nil || config.Port != 8080 {
+			t.Errorf(`expected the default port, but received %v due to %v`, config.Port, err)
+		}
+		config, err = parse(`-mode`, `prod`, `-port`, `1`, `-p`, `2`)
+		if err != nil || config.Port != 2 {
+			t.Errorf(`expected the last port 2, but received %v due to %v`, config.Port, err)
+		}
+	})
+
+	t.Run(`test multiple values policy`, func(t *testing.T) {
+		fs := flag.NewFlagSet(`paramex`, flag.ContinueOnError)
+		config := struct {
+			Mode string `param:"mode,multi=first"`
+			Port int    `param:"port"`
+		}{}
+		binding, err := RegisterFlags(fs, &config, WithMultipleValues(RejectMultipleValues))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = fs.Parse([]string{`-mode`, `dev`, `-mode`, `prod`, `-port`, `1`, `-port`, `2`})
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = binding.Bind()
+		if _, ok := err.(ErrorMultipleValues); !ok || config.Mode != `dev` {
+			t.Errorf(`expected "ErrorMultipleValues" of port and the first mode, but received %v and %v`, err, config.Mode)
+		}
+	})
+
+	t.Run(`test errors`, func(t *testing.T) {
+		_, err := parse()
+		if _, ok := err.(ErrorRequiredParam); !ok {
+			t.Errorf(`expected "ErrorRequiredParam", but received %v`, err)
+		}
+		_, err = parse(`-mode`, `test`)
+		if _, ok := err.(ErrorInvalidValue); !ok {
+			t.Errorf(`expected "ErrorInvalidValue", but received %v`, err)
+		}
+		_, err = parse(`-mode`, `dev`, `-port`, `http`)
+		if _, ok := err.(ErrorUnmarshalType); !ok {
+			t.Errorf(`expected "ErrorUnmarshalType", but received %v`, err)
+		}
+		_, err = RegisterFlags(flag.NewFlagSet(`paramex`, flag.ContinueOnError), flagConfig{})
+		if _, ok := err.(ErrorNotAssignable); !ok {
+			t.Errorf(`expected "ErrorNotAssignable", but received %v`, err)
+		}
+	})
+
+	t.Run(`test usage`, func(t *testing.T) {
+		fs := flag.NewFlagSet(`paramex`, flag.ContinueOnError)
+		var usage strings.Builder
+		fs.SetOutput(&usage)
+		_, err := RegisterFlags(fs, &flagConfig{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		fs.PrintDefaults()
+		if !strings.Contains(usage.String(), `listening port (default 8080)`) {
+			t.Errorf(`unexpected usage %v`, usage.String())
+		}
+	})
+}
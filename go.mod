@@ -2,4 +2,4 @@ module github.com/senpathi/paramex
 
 require github.com/google/uuid v1.2.0
 
-go 1.13
+go 1.22
@@ -0,0 +1,170 @@
+package paramex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+)
+
+// HandlerOption configures the http.Handler returned by NewHandler
+type HandlerOption func(*handlerConfig)
+
+// BinderFunc binds parameters of req to v. v is always a Go struct reference
+type BinderFunc func(v interface{}, req *http.Request) error
+
+// ErrorWriterFunc writes the response of a failed request with the given status code
+type ErrorWriterFunc func(w http.ResponseWriter, req *http.Request, err error, status int)
+
+type handlerConfig struct {
+	extractor   Extractor
+	binder      BinderFunc
+	statusFuncs []func(err error) int
+	errorWriter ErrorWriterFunc
+}
+
+// WithExtractor sets the Extractor used by the default binder.
+// NewParamExtractor() is used when not set. Extractors not implementing ParamExtractor
+// bind only url query values and form values
+func WithExtractor(extractor Extractor) HandlerOption {
+	return func(c *handlerConfig) {
+		c.extractor = extractor
+	}
+}
+
+// WithBinder replaces the default binder, which binds every location declared by the handler
+// parameters and the request body using ParamExtractor.BindRequest, and sets warning headers
+// of deprecated parameters using SetWarningHeaders
+func WithBinder(binder BinderFunc) HandlerOption {
+	return func(c *handlerConfig) {
+		c.binder = binder
+	}
+}
+
+// WithStatusMapper adds a hook mapping errors to http status codes.
+// Hooks are called in the order they are added and the first non zero
+// status code is used. Errors not mapped by any hook get the status code
+// reported by StatusCoder, or 500 when the error is not a StatusCoder
+func WithStatusMapper(mapper func(err error) int) HandlerOption {
+	return func(c *handlerConfig) {
+		c.statusFuncs = append(c.statusFuncs, mapper)
+	}
+}
+
+// WithErrorWriter replaces the default error writer, which responds with
+// a JSON object of the form {"error": "<message>"}
+func WithErrorWriter(writer ErrorWriterFunc) HandlerOption {
+	return func(c *handlerConfig) {
+		c.errorWriter = writer
+	}
+}
+
+type handler[P, R any] struct {
+	fn     func(ctx context.Context, params P) (R, error)
+	config handlerConfig
+}
+
+// NewHandler returns an http.Handler which binds request parameters to a P,
+// calls fn with them and writes the returned R as a JSON response.
+// P should be a Go struct or a Go struct reference annotated with param.
+//
+// Binding errors and errors returned by fn are written by the error writer with
+// the status code resolved by the status mappers
+func NewHandler[P, R any](fn func(ctx context.Context, params P) (R, error), opts ...HandlerOption) http.Handler {
+	h := &handler[P, R]{fn: fn}
+	for _, opt := range opts {
+		opt(&h.config)
+	}
+	if h.config.extractor == nil {
+		h.config.extractor = NewParamExtractor()
+	}
+	if h.config.errorWriter == nil {
+		h.config.errorWriter = writeJSONError
+	}
+
+	return h
+}
+
+func (h *handler[P, R]) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var params P
+	target := interface{}(&params)
+	if t := reflect.TypeOf(params); t != nil && t.Kind() == reflect.Ptr {
+		ref := reflect.New(t.Elem())
+		reflect.ValueOf(&params).Elem().Set(ref)
+		target = ref.Interface()
+	}
+
+	err := h.bind(w, target, req)
+	if err != nil {
+		h.writeError(w, req, err)
+		return
+	}
+
+	resp, err := h.fn(req.Context(), params)
+	if err != nil {
+		h.writeError(w, req, err)
+		return
+	}
+
+	body := bytes.Buffer{}
+	err = json.NewEncoder(&body).Encode(resp)
+	if err != nil {
+		h.writeError(w, req, err)
+		return
+	}
+
+	w.Header().Set(`Content-Type`, `application/json`)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body.Bytes())
+}
+
+func (h *handler[P, R]) bind(w http.ResponseWriter, v interface{}, req *http.Request) error {
+	if h.config.binder != nil {
+		return h.config.binder(v, req)
+	}
+
+	extractor, ok := h.config.extractor.(ParamExtractor)
+	if !ok {
+		err := h.config.extractor.ExtractQueries(v, req)
+		if err != nil {
+			return err
+		}
+		return h.config.extractor.ExtractForms(v, req)
+	}
+
+	result, err := extractor.BindRequest(v, req)
+	if err != nil {
+		return err
+	}
+
+	SetWarningHeaders(w.Header(), result)
+	return nil
+}
+
+func (h *handler[P, R]) writeError(w http.ResponseWriter, req *http.Request, err error) {
+	status := 0
+	for _, mapper := range h.config.statusFuncs {
+		status = mapper(err)
+		if status != 0 {
+			break
+		}
+	}
+	if status == 0 {
+		status = statusCode(err)
+	}
+
+	h.config.errorWriter(w, req, err, status)
+}
+
+// writeJSONError hides messages of server errors from clients
+func writeJSONError(w http.ResponseWriter, _ *http.Request, err error, status int) {
+	message := err.Error()
+	if status >= http.StatusInternalServerError {
+		message = http.StatusText(status)
+	}
+
+	w.Header().Set(`Content-Type`, `application/json`)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{`error`: message})
+}
@@ -0,0 +1,203 @@
+package paramex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type handlerParams struct {
+	Name string `param:"name"`
+	Age  int    `param:"age"`
+}
+
+type handlerResp struct {
+	Greeting string `json:"greeting"`
+}
+
+func greet(_ context.Context, params handlerParams) (handlerResp, error) {
+	if params.Name == `nobody` {
+		return handlerResp{}, errors.New(`database is down`)
+	}
+	return handlerResp{Greeting: fmt.Sprintf(`%s is %d`, params.Name, params.Age)}, nil
+}
+
+func TestNewHandler(t *testing.T) {
+	t.Run(`test binds queries and encodes response`, func(t *testing.T) {
+		req := httptest.NewRequest(`GET`, `https://nipuna.lk?name=nipuna&age=35`, nil)
+		rec := httptest.NewRecorder()
+		NewHandler(greet).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf(`expected [%d], but received [%d]`, http.StatusOK, rec.Code)
+		}
+		if rec.Header().Get(`Content-Type`) != `application/json` {
+			t.Errorf(`expected [application/json], but received [%v]`, rec.Header().Get(`Content-Type`))
+		}
+		resp := handlerResp{}
+		err := json.NewDecoder(rec.Body).Decode(&resp)
+		if err != nil {
+			t.Fatalf(`error decoding response due to %v`, err)
+		}
+		if resp.Greeting != `nipuna is 35` {
+			t.Errorf(`expected [nipuna is 35], but received [%v]`, resp.Greeting)
+		}
+	})
+
+	t.Run(`test binds forms into struct reference`, func(t *testing.T) {
+		req := httptest.NewRequest(`POST`, `https://nipuna.lk?age=20`, strings.NewReader(`name=form_name`))
+		req.Header.Set(`Content-Type`, `application/x-www-form-urlencoded`)
+		rec := httptest.NewRecorder()
+		NewHandler(func(ctx context.Context, params *handlerParams) (handlerResp, error) {
+			return greet(ctx, *params)
+		}).ServeHTTP(rec, req)
+
+		if !strings.Contains(rec.Body.String(), `form_name is 20`) {
+			t.Errorf(`expected [form_name is 20], but received [%v]`, rec.Body.String())
+		}
+	})
+
+	t.Run(`test deprecated params warning headers`, func(t *testing.T) {
+		req := httptest.NewRequest(`GET`, `https://nipuna.lk?username=nipuna`, nil)
+		rec := httptest.NewRecorder()
+		NewHandler(func(ctx context.Context, params struct {
+			Name string `param:"name|username,deprecated=use name"`
+		}) (handlerResp, error) {
+			return handlerResp{Greeting: params.Name}, nil
+		}).ServeHTTP(rec, req)
+
+		if rec.Header().Get(`Deprecation`) != `true` {
+			t.Errorf(`expected [true], but received [%v]`, rec.Header().Get(`Deprecation`))
+		}
+		if rec.Header().Get(`Warning`) != `299 - "param \"username\" is deprecated, use name"` {
+			t.Errorf(`unexpected warning header [%v]`, rec.Header().Get(`Warning`))
+		}
+	})
+
+	t.Run(`test binding error`, func(t *testing.T) {
+		req := httptest.NewRequest(`GET`, `https://nipuna.lk?age=old`, nil)
+		rec := httptest.NewRecorder()
+		NewHandler(greet).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf(`expected [%d], but received [%d]`, http.StatusBadRequest, rec.Code)
+		}
+		if !strings.Contains(rec.Body.String(), `error unmarshalling [old] into [int]`) {
+			t.Errorf(`unexpected error response [%v]`, rec.Body.String())
+		}
+	})
+
+	t.Run(`test handler error hides message`, func(t *testing.T) {
+		req := httptest.NewRequest(`GET`, `https://nipuna.lk?name=nobody`, nil)
+		rec := httptest.NewRecorder()
+		NewHandler(greet).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf(`expected [%d], but received [%d]`, http.StatusInternalServerError, rec.Code)
+		}
+		if strings.Contains(rec.Body.String(), `database`) {
+			t.Errorf(`unexpected error response [%v]`, rec.Body.String())
+		}
+	})
+
+	t.Run(`test status mapper and error writer hooks`, func(t *testing.T) {
+		req := httptest.NewRequest(`GET`, `https://nipuna.lk?name=nobody`, nil)
+		rec := httptest.NewRecorder()
+		NewHandler(greet,
+			WithStatusMapper(func(err error) int { return 0 }),
+			WithStatusMapper(func(err error) int { return http.StatusServiceUnavailable }),
+			WithErrorWriter(func(w http.ResponseWriter, _ *http.Request, err error, status int) {
+				w.WriteHeader(status)
+				_, _ = w.Write([]byte(err.Error()))
+			}),
+		).ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Errorf(`expected [%d], but received [%d]`, http.StatusServiceUnavailable, rec.Code)
+		}
+		if rec.Body.String() != `database is down` {
+			t.Errorf(`expected [database is down], but received [%v]`, rec.Body.String())
+		}
+	})
+
+	t.Run(`test custom binder`, func(t *testing.T) {
+		req := httptest.NewRequest(`GET`, `https://nipuna.lk`, nil)
+		req.Header.Set(`name`, `header_name`)
+		rec := httptest.NewRecorder()
+		NewHandler(greet, WithBinder(func(v interface{}, req *http.Request) error {
+			return NewParamExtractor().ExtractHeaders(v, req)
+		})).ServeHTTP(rec, req)
+
+		if !strings.Contains(rec.Body.String(), `header_name is 0`) {
+			t.Errorf(`expected [header_name is 0], but received [%v]`, rec.Body.String())
+		}
+	})
+
+	t.Run(`test binds every declared location`, func(t *testing.T) {
+		type itemParams struct {
+			ID      int    `param:"id,in=path"`
+			Trace   string `param:"X-Trace-Id,in=header"`
+			Session string `param:"session,in=cookie"`
+			Name    string `param:"name"`
+		}
+		req := httptest.NewRequest(`GET`, `https://nipuna.lk/items/7?name=nipuna`, nil)
+		req.SetPathValue(`id`, `7`)
+		req.Header.Set(`X-Trace-Id`, `trace`)
+		req.AddCookie(&http.Cookie{Name: `session`, Value: `abc`})
+		rec := httptest.NewRecorder()
+		NewHandler(func(_ context.Context, params itemParams) (itemParams, error) {
+			return params, nil
+		}).ServeHTTP(rec, req)
+
+		expected := `{"ID":7,"Trace":"trace","Session":"abc","Name":"nipuna"}`
+		if strings.TrimSpace(rec.Body.String()) != expected {
+			t.Errorf(`expected [%v], but received [%v]`, expected, rec.Body.String())
+		}
+	})
+
+	t.Run(`test required and default params`, func(t *testing.T) {
+		type listParams struct {
+			Name string `param:"name,required"`
+			Sort string `param:"sort,default=asc"`
+		}
+		handler := NewHandler(func(_ context.Context, params listParams) (listParams, error) {
+			return params, nil
+		})
+		tests := []struct {
+			url      string
+			status   int
+			expected string
+		}{
+			{`https://nipuna.lk?name=a`, http.StatusOK, `{"Name":"a","Sort":"asc"}`},
+			{`https://nipuna.lk?name=a&sort=desc`, http.StatusOK, `{"Name":"a","Sort":"desc"}`},
+			{`https://nipuna.lk?sort=desc`, http.StatusBadRequest, `{"error":"required query param \"name\" is missing"}`},
+		}
+		for _, test := range tests {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(`GET`, test.url, nil))
+			if rec.Code != test.status || strings.TrimSpace(rec.Body.String()) != test.expected {
+				t.Errorf(`expected [%d %v], but received [%d %v]`, test.status, test.expected, rec.Code, rec.Body.String())
+			}
+		}
+	})
+
+	t.Run(`test extractor implementing only Extractor`, func(t *testing.T) {
+		req := httptest.NewRequest(`GET`, `https://nipuna.lk?name=nipuna&age=35`, nil)
+		rec := httptest.NewRecorder()
+		NewHandler(greet, WithExtractor(queryExtractor{NewParamExtractor()})).ServeHTTP(rec, req)
+
+		if !strings.Contains(rec.Body.String(), `nipuna is 35`) {
+			t.Errorf(`expected [nipuna is 35], but received [%v]`, rec.Body.String())
+		}
+	})
+}
+
+// queryExtractor implements only the Extractor interface, same as mocks of Extractor
+type queryExtractor struct {
+	Extractor
+}
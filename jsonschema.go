@@ -0,0 +1,121 @@
+package paramex
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// JSONSchemaDialect is the dialect of schemas returned by JSONSchema
+const JSONSchemaDialect = `https://json-schema.org/draft/2020-12/schema`
+
+// JSONSchema returns a JSON Schema Draft 2020-12 describing params of the in location of v, which
+// should be a Go struct or a Go struct reference, as an object, e.g. the url query of a request.
+// Fields located in other locations by the in tag option are skipped.
+//
+// Alias keys are properties having the schema of their field, keys captured by a prefix are pattern
+// properties, and WithStrict disallows additional properties except the allowed keys. Equivalent keys
+// of a key normalizer set by WithKeyNormalizer can not be described
+func JSONSchema(v interface{}, in In, opts ...Option) (*Schema, error) {
+	o := newOptions(opts)
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, ErrorUnSupportedType{fmt.Errorf(`type of %v is not describable, required struct object`, t)}
+	}
+
+	schema := &Schema{Schema: JSONSchemaDialect, Type: `object`, Properties: map[string]*Schema{}}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := o.fieldTag(field)
+		if !ok || !tag.located(in, ``) {
+			continue
+		}
+
+		keys := tag.keys()
+		prm := param{field: field, key: keys[0], keys: keys, in: in, tag: tag}
+		if tag.prefix() && field.Type.Kind() == reflect.Map {
+			values, err := o.schema(field.Type.Elem(), prm)
+			if err != nil {
+				return nil, err
+			}
+			if schema.PatternProperties == nil {
+				schema.PatternProperties = map[string]*Schema{}
+			}
+			for _, key := range keys {
+				schema.PatternProperties[`^`+regexp.QuoteMeta(strings.TrimSuffix(key, `*`))] = values
+			}
+			continue
+		}
+
+		property, err := o.fieldSchema(prm)
+		if err != nil {
+			return nil, err
+		}
+		if note, ok := tag.option(`deprecated`); ok {
+			property.Deprecated, property.Description = true, note
+		}
+		for _, key := range keys {
+			schema.Properties[key] = property
+		}
+
+		if _, required := tag.option(`required`); !required {
+			continue
+		}
+		if len(keys) == 1 {
+			schema.Required = append(schema.Required, keys[0])
+			continue
+		}
+		anyKey := &Schema{}
+		for _, key := range keys {
+			anyKey.AnyOf = append(anyKey.AnyOf, &Schema{Required: []string{key}})
+		}
+		schema.AllOf = append(schema.AllOf, anyKey)
+	}
+
+	if o.strict {
+		schema.AdditionalProperties = false
+		for _, allowed := range o.allowedKeys {
+			if schema.PatternProperties == nil {
+				schema.PatternProperties = map[string]*Schema{}
+			}
+			schema.PatternProperties[globPattern(allowed)] = &Schema{}
+		}
+	}
+	return schema, nil
+}
+
+// globPattern converts a path.Match pattern into a regular expression matching the same keys
+func globPattern(glob string) string {
+	var b strings.Builder
+	b.WriteString(`^`)
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			b.WriteString(`[^/]*`)
+		case '?':
+			b.WriteString(`[^/]`)
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+			}
+		case '[':
+			end := strings.IndexByte(glob[i:], ']')
+			if end < 0 {
+				b.WriteString(regexp.QuoteMeta(glob[i:]))
+				i = len(glob)
+				continue
+			}
+			b.WriteString(glob[i : i+end+1])
+			i += end
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString(`$`)
+	return b.String()
+}
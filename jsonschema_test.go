@@ -0,0 +1,165 @@
+package paramex
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type jsonSchemaParams struct {
+	ID     uuid.UUID         `param:"id,required"`
+	Code   string            `param:"code,pattern=^[A-Z]{3}$"`
+	Name   string            `param:"name|title,required,minLength=2,maxLength=10"`
+	Tags   []string          `param:"tags,minItems=1,maxItems=3,maxLength=5"`
+	Limit  int64             `param:"limit,min=1,max=100,deprecated"`
+	Filter openAPIFilter     `param:"filter"`
+	Meta   map[string]string `param:"meta.*"`
+	Trace  string            `param:"X-Trace-Id,in=header"`
+}
+
+func TestJSONSchema(t *testing.T) {
+	t.Run(`test schema`, func(t *testing.T) {
+		schema, err := JSONSchema(jsonSchemaParams{}, InQuery, WithStrict(`utm_*`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		encoded, err := json.MarshalIndent(schema, ``, `  `)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "code": {
+      "type": "string",
+      "pattern": "^[A-Z]{3}$"
+    },
+    "filter": {
+      "type": "object",
+      "properties": {
+        "owner": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "status": {
+          "type": "string",
+          "enum": [
+            "open",
+            "closed"
+          ]
+        }
+      },
+      "required": [
+        "owner"
+      ]
+    },
+    "id": {
+      "type": "string",
+      "format": "uuid"
+    },
+    "limit": {
+      "type": "integer",
+      "format": "int64",
+      "deprecated": true,
+      "minimum": 1,
+      "maximum": 100
+    },
+    "name": {
+      "type": "string",
+      "minLength": 2,
+      "maxLength": 10
+    },
+    "tags": {
+      "type": "array",
+      "items": {
+        "type": "string",
+        "maxLength": 5
+      },
+      "minItems": 1,
+      "maxItems": 3
+    },
+    "title": {
+      "type": "string",
+      "minLength": 2,
+      "maxLength": 10
+    }
+  },
+  "patternProperties": {
+    "^meta\\.": {
+      "type": "string"
+    },
+    "^utm_[^/]*$": {}
+  },
+  "additionalProperties": false,
+  "required": [
+    "id"
+  ],
+  "allOf": [
+    {
+      "anyOf": [
+        {
+          "required": [
+            "name"
+          ]
+        },
+        {
+          "required": [
+            "title"
+          ]
+        }
+      ]
+    }
+  ]
+}`
+		if string(encoded) != expected {
+			t.Errorf(`unexpected schema %s`, encoded)
+		}
+	})
+
+	t.Run(`test glob patterns`, func(t *testing.T) {
+		tests := map[string]string{
+			`utm_*`:     `^utm_[^/]*$`,
+			`fbclid`:    `^fbclid$`,
+			`v?.[a-c]`:  `^v[^/]\.[a-c]$`,
+			`a\*b`:      `^a\*b$`,
+			`unclosed[`: `^unclosed\[$`,
+		}
+		for glob, expected := range tests {
+			if pattern := globPattern(glob); pattern != expected {
+				t.Errorf(`expected pattern %v of %v, but received %v`, expected, glob, pattern)
+			}
+		}
+	})
+}
+
+func TestExtractor_LengthConstraints(t *testing.T) {
+	extractor := NewParamExtractor()
+	tests := []struct {
+		query string
+		valid bool
+	}{
+		{`id=` + uuid.NewString() + `&name=ab&code=ABC&tags=abcde`, true},
+		{`id=` + uuid.NewString() + `&title=ab`, true},
+		{`id=` + uuid.NewString() + `&name=a`, false},
+		{`id=` + uuid.NewString() + `&name=abcdefghijk`, false},
+		{`id=` + uuid.NewString() + `&name=ab&code=AB`, false},
+		{`id=` + uuid.NewString() + `&name=ab&code=abc`, false},
+		{`id=` + uuid.NewString() + `&name=ab&tags=a&tags=b&tags=c&tags=d`, false},
+		{`id=` + uuid.NewString() + `&name=ab&tags=abcdef`, false},
+	}
+	for _, test := range tests {
+		req, _ := http.NewRequest(`GET`, `https://nipuna.lk?`+test.query, nil)
+		obj := jsonSchemaParams{}
+		err := extractor.ExtractQueries(&obj, req)
+		if test.valid && err != nil {
+			t.Errorf(`%v: unexpected error %v`, test.query, err)
+		}
+		if _, ok := err.(ErrorInvalidValue); !test.valid && !ok {
+			t.Errorf(`%v: expected "ErrorInvalidValue", but received %v`, test.query, err)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package paramex
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Parameter is an OpenAPI 3 parameter object describing a field
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          In      `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Deprecated  bool    `json:"deprecated,omitempty"`
+	Style       string  `json:"style,omitempty"`
+	Explode     *bool   `json:"explode,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// Schema is an OpenAPI 3 schema object describing values of a field, which is
+// also a JSON Schema returned by JSONSchema
+type Schema struct {
+	Schema      string             `json:"$schema,omitempty"`
+	Ref         string             `json:"$ref,omitempty"`
+	Type        string             `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Deprecated  bool               `json:"deprecated,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	// PatternProperties describe properties having keys matching a regular expression
+	PatternProperties map[string]*Schema `json:"patternProperties,omitempty"`
+	// AdditionalProperties is either a *Schema or false, which disallows undeclared properties
+	AdditionalProperties interface{}   `json:"additionalProperties,omitempty"`
+	Required             []string      `json:"required,omitempty"`
+	AnyOf                []*Schema     `json:"anyOf,omitempty"`
+	AllOf                []*Schema     `json:"allOf,omitempty"`
+	Default              interface{}   `json:"default,omitempty"`
+	Enum                 []interface{} `json:"enum,omitempty"`
+	Minimum              *float64      `json:"minimum,omitempty"`
+	Maximum              *float64      `json:"maximum,omitempty"`
+	Pattern              string        `json:"pattern,omitempty"`
+	MinLength            *int          `json:"minLength,omitempty"`
+	MaxLength            *int          `json:"maxLength,omitempty"`
+	MinItems             *int          `json:"minItems,omitempty"`
+	MaxItems             *int          `json:"maxItems,omitempty"`
+}
+
+var defaultSchemas = map[reflect.Type]Schema{
+	stringType:  {Type: `string`},
+	boolType:    {Type: `boolean`},
+	int32Type:   {Type: `integer`, Format: `int32`},
+	intType:     {Type: `integer`, Format: `int64`},
+	int64Type:   {Type: `integer`, Format: `int64`},
+	float32Type: {Type: `number`, Format: `float`},
+	float64Type: {Type: `number`, Format: `double`},
+	uuidType:    {Type: `string`, Format: `uuid`},
+	timeType:    {Type: `string`, Format: `date-time`},
+}
+
+// OpenAPIParameters returns OpenAPI 3 parameter objects describing fields of v, which should be
+// a Go struct or a Go struct reference. Fields are located by the in tag option, fields without
+// the option are located in fallback, e.g. InQuery.
+//
+// Form fields are described by request bodies instead of parameters, and fields capturing keys by
+// a prefix, e.g. `param:"meta.*"`, can not be described by parameters, therefore both are skipped
+func OpenAPIParameters(v interface{}, fallback In, opts ...Option) ([]Parameter, error) {
+	o := newOptions(opts)
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, ErrorUnSupportedType{fmt.Errorf(`type of %v is not describable, required struct object`, t)}
+	}
+
+	var params []Parameter
+	for _, in := range []In{InPath, InQuery, InHeader, InCookie} {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			tag, ok := o.fieldTag(field)
+			if !ok || !tag.located(in, fallback) || tag.prefix() && field.Type.Kind() == reflect.Map {
+				continue
+			}
+
+			keys := tag.keys()
+			prm := param{field: field, key: keys[0], keys: keys, in: in, tag: tag}
+			parameter, err := o.parameter(prm)
+			if err != nil {
+				return nil, err
+			}
+			params = append(params, parameter)
+		}
+	}
+	return params, nil
+}
+
+// parameter returns the parameter object of prm
+func (o options) parameter(prm param) (Parameter, error) {
+	schema, err := o.fieldSchema(prm)
+	if err != nil {
+		return Parameter{}, err
+	}
+	c, _ := prm.tag.constraints()
+	parameter := Parameter{
+		Name:     prm.key,
+		In:       prm.in,
+		Required: c.required || prm.in == InPath,
+		Schema:   schema,
+	}
+	if note, ok := prm.tag.option(`deprecated`); ok {
+		parameter.Deprecated = true
+		parameter.Description = note
+	}
+
+	style, styled, err := o.style(prm)
+	if err != nil {
+		return Parameter{}, err
+	}
+	if _, explicit := prm.tag.option(`style`); explicit {
+		parameter.Style, parameter.Explode = style.name, &style.explode
+	} else if !styled && prm.in.keyed() && o.nested(prm.field.Type) {
+		explode := true
+		parameter.Style, parameter.Explode = styleDeepObject, &explode
+	}
+	return parameter, nil
+}
+
+// nested reports whether fields of type t are bound from bracket notation keys
+func (o options) nested(t reflect.Type) bool {
+	return extractor{opts: o}.nested(t)
+}
+
+// fieldSchema returns the schema of prm, having the constraints of the field tag. Enum, minimum
+// and maximum constraints of array fields describe the items, same as they are validated
+func (o options) fieldSchema(prm param) (*Schema, error) {
+	schema, err := o.schema(prm.field.Type, prm)
+	if err != nil {
+		return nil, err
+	}
+	c, err := prm.tag.constraints()
+	if err != nil {
+		return nil, ErrorUnSupportedParamType{prm.fieldError(err)}
+	}
+
+	if c.hasDefault {
+		schema.Default, err = o.defaultValue(prm, c.defaultValue)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	constrained := schema
+	t := prm.field.Type
+	if _, ok := o.converter(t); !ok && t.Kind() == reflect.Slice && schema.Items != nil {
+		constrained, t = schema.Items, t.Elem()
+		schema.MinItems, schema.MaxItems = c.minItems, c.maxItems
+	}
+	constrained.Minimum, constrained.Maximum = c.min, c.max
+	constrained.MinLength, constrained.MaxLength = c.minLength, c.maxLength
+	if c.pattern != nil {
+		constrained.Pattern = c.pattern.String()
+	}
+	for _, str := range c.enum {
+		value, err := o.schemaValue(prm, t, str)
+		if err != nil {
+			return nil, err
+		}
+		constrained.Enum = append(constrained.Enum, value)
+	}
+	return schema, nil
+}
+
+// defaultValue returns the default value of prm converted into the field type
+func (o options) defaultValue(prm param, str string) (interface{}, error) {
+	t := prm.field.Type
+	if _, ok := o.converter(t); ok || t.Kind() != reflect.Slice {
+		return o.schemaValue(prm, t, str)
+	}
+	var values []interface{}
+	for _, s := range splitEscaped(str, '|') {
+		value, err := o.schemaValue(prm, t.Elem(), s)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// schemaValue returns str converted into a value of type t, which is marshalled as a JSON value
+func (o options) schemaValue(prm param, t reflect.Type, str string) (interface{}, error) {
+	c, ok := o.converter(t)
+	if !ok {
+		return nil, ErrorUnSupportedParamType{prm.fieldError(
+			fmt.Errorf(`value "%v" of type %v can not be described`, str, t))}
+	}
+	value, err := c.convertTo(prm, t, str)
+	if err != nil {
+		return nil, ErrorUnSupportedParamType{prm.fieldError(err)}
+	}
+	return value.Interface(), nil
+}
+
+// schema returns the schema of type t. Types having a converter without a known schema are strings
+func (o options) schema(t reflect.Type, prm param) (*Schema, error) {
+	if s, ok := defaultSchemas[t]; ok {
+		return &s, nil
+	}
+	if _, ok := o.converter(t); ok {
+		return &Schema{Type: `string`}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		items, err := o.schema(t.Elem(), prm)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: `array`, Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			break
+		}
+		values, err := o.schema(t.Elem(), prm)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: `object`, AdditionalProperties: values}, nil
+	case reflect.Struct:
+		schema := &Schema{Type: `object`, Properties: map[string]*Schema{}}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			tag, ok := o.fieldTag(field)
+			if !ok {
+				continue
+			}
+			keys := tag.keys()
+			nested := param{field: field, path: prm.path + `.` + field.Name, key: keys[0], keys: keys, in: prm.in, tag: tag}
+			if prm.path == `` {
+				nested.path = prm.field.Name + `.` + field.Name
+			}
+			property, err := o.fieldSchema(nested)
+			if err != nil {
+				return nil, err
+			}
+			schema.Properties[nested.key] = property
+			if _, required := tag.option(`required`); required {
+				schema.Required = append(schema.Required, nested.key)
+			}
+		}
+		return schema, nil
+	}
+	return nil, ErrorUnSupportedParamType{prm.fieldError(
+		fmt.Errorf(`type %v of "%v" can not be described`, t, prm.key))}
+}
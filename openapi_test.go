@@ -0,0 +1,251 @@
+package paramex
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type openAPIFilter struct {
+	Status string `param:"status,enum=open|closed"`
+	Owner  int64  `param:"owner,required"`
+}
+
+type openAPIParams struct {
+	ID       uuid.UUID         `param:"id,in=path"`
+	Limit    int32             `param:"limit,default=20,min=1,max=100"`
+	Sort     string            `param:"sort,default=asc,enum=asc|desc"`
+	SortBy   string            `param:"sort_by,deprecated=use sort"`
+	Ratio    float32           `param:"ratio"`
+	Score    float64           `param:"score"`
+	Since    time.Time         `param:"since"`
+	Active   bool              `param:"active,required"`
+	Sizes    []int             `param:"sizes,style=pipeDelimited,max=10"`
+	Filter   openAPIFilter     `param:"filter"`
+	Meta     map[string]string `param:"meta.*"`
+	TraceID  string            `param:"X-Trace-Id,in=header"`
+	Session  string            `param:"session,in=cookie"`
+	Note     string            `param:"note,in=form"`
+	internal string
+}
+
+func TestOpenAPIParameters(t *testing.T) {
+	t.Run(`test parameters`, func(t *testing.T) {
+		params, err := OpenAPIParameters(&openAPIParams{}, InQuery)
+		if err != nil {
+			t.Fatal(err)
+		}
+		encoded, err := json.MarshalIndent(params, ``, `  `)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := `[
+  {
+    "name": "id",
+    "in": "path",
+    "required": true,
+    "schema": {
+      "type": "string",
+      "format": "uuid"
+    }
+  },
+  {
+    "name": "limit",
+    "in": "query",
+    "schema": {
+      "type": "integer",
+      "format": "int32",
+      "default": 20,
+      "minimum": 1,
+      "maximum": 100
+    }
+  },
+  {
+    "name": "sort",
+    "in": "query",
+    "schema": {
+      "type": "string",
+      "default": "asc",
+      "enum": [
+        "asc",
+        "desc"
+      ]
+    }
+  },
+  {
+    "name": "sort_by",
+    "in": "query",
+    "description": "use sort",
+    "deprecated": true,
+    "schema": {
+      "type": "string"
+    }
+  },
+  {
+    "name": "ratio",
+    "in": "query",
+    "schema": {
+      "type": "number",
+      "format": "float"
+    }
+  },
+  {
+    "name": "score",
+    "in": "query",
+    "schema": {
+      "type": "number",
+      "format": "double"
+    }
+  },
+  {
+    "name": "since",
+    "in": "query",
+    "schema": {
+      "type": "string",
+      "format": "date-time"
+    }
+  },
+  {
+    "name": "active",
+    "in": "query",
+    "required": true,
+    "schema": {
+      "type": "boolean"
+    }
+  },
+  {
+    "name": "sizes",
+    "in": "query",
+    "style": "pipeDelimited",
+    "explode": false,
+    "schema": {
+      "type": "array",
+      "items": {
+        "type": "integer",
+        "format": "int64",
+        "maximum": 10
+      }
+    }
+  },
+  {
+    "name": "filter",
+    "in": "query",
+    "style": "deepObject",
+    "explode": true,
+    "schema": {
+      "type": "object",
+      "properties": {
+        "owner": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "status": {
+          "type": "string",
+          "enum": [
+            "open",
+            "closed"
+          ]
+        }
+      },
+      "required": [
+        "owner"
+      ]
+    }
+  },
+  {
+    "name": "X-Trace-Id",
+    "in": "header",
+    "schema": {
+      "type": "string"
+    }
+  },
+  {
+    "name": "session",
+    "in": "cookie",
+    "schema": {
+      "type": "string"
+    }
+  }
+]`
+		if string(encoded) != expected {
+			t.Errorf(`unexpected parameters %s`, encoded)
+		}
+	})
+
+	t.Run(`test fallback location`, func(t *testing.T) {
+		params, err := OpenAPIParameters(headerParams{}, InHeader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(params) != 4 || params[0].In != InHeader || params[0].Name != `name` {
+			t.Errorf(`unexpected parameters %+v`, params)
+		}
+	})
+
+	t.Run(`test errors`, func(t *testing.T) {
+		_, err := OpenAPIParameters(5, InQuery)
+		if _, ok := err.(ErrorUnSupportedType); !ok {
+			t.Errorf(`expected "ErrorUnSupportedType", but received %v`, err)
+		}
+
+		_, err = OpenAPIParameters(struct {
+			Limit int `param:"limit,default=many"`
+		}{}, InQuery)
+		if _, ok := err.(ErrorUnSupportedParamType); !ok {
+			t.Errorf(`expected "ErrorUnSupportedParamType", but received %v`, err)
+		}
+	})
+}
+
+func TestExtractor_Constraints(t *testing.T) {
+	type constrained struct {
+		Limit int      `param:"limit,default=20,min=1,max=100"`
+		Sort  string   `param:"sort,enum=asc|desc"`
+		Sizes []int    `param:"sizes,max=10"`
+		Tags  []string `param:"tags,default=a|b"`
+		Page  int      `param:"page,required"`
+	}
+	extractor := NewParamExtractor(WithErrorAggregation())
+	request := func(query string) *http.Request {
+		req, _ := http.NewRequest(`GET`, `https://nipuna.lk?`+query, nil)
+		return req
+	}
+
+	t.Run(`test valid values and defaults`, func(t *testing.T) {
+		obj := constrained{}
+		err := extractor.ExtractQueries(&obj, request(`sort=desc&sizes=1&sizes=10&page=1`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if obj.Limit != 20 || obj.Sort != `desc` || len(obj.Tags) != 2 || obj.Tags[1] != `b` {
+			t.Errorf(`unexpected values %+v`, obj)
+		}
+	})
+
+	t.Run(`test violated constraints`, func(t *testing.T) {
+		obj := constrained{}
+		err := extractor.ExtractQueries(&obj, request(`limit=0&sort=up&sizes=11`))
+		errs, ok := err.(Errors)
+		if !ok || len(errs) != 4 {
+			t.Fatalf(`expected 4 errors, but received %v`, err)
+		}
+		for _, err := range errs[:3] {
+			if _, ok := err.(ErrorInvalidValue); !ok {
+				t.Errorf(`expected "ErrorInvalidValue", but received %v`, err)
+			}
+		}
+		if _, ok := errs[3].(ErrorRequiredParam); !ok {
+			t.Errorf(`expected "ErrorRequiredParam", but received %v`, errs[3])
+		}
+		if errs[0].Error() != `value [0] of param "limit" is less than the minimum 1` {
+			t.Errorf(`unexpected error %v`, errs[0])
+		}
+		if errs[3].Error() != `required query param "page" is missing` {
+			t.Errorf(`unexpected error %v`, errs[3])
+		}
+	})
+}
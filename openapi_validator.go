@@ -0,0 +1,562 @@
+package paramex
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// openAPIDocument is the part of an OpenAPI 3 document describing parameters
+type openAPIDocument struct {
+	Servers []struct {
+		URL string `json:"url"`
+	} `json:"servers"`
+	Paths      map[string]openAPIPathItem `json:"paths"`
+	Components struct {
+		Parameters map[string]*openAPIParameter `json:"parameters"`
+		Schemas    map[string]*Schema           `json:"schemas"`
+	} `json:"components"`
+}
+
+type openAPIPathItem struct {
+	Parameters []*openAPIParameter `json:"parameters"`
+	Get        *openAPIOperation   `json:"get"`
+	Put        *openAPIOperation   `json:"put"`
+	Post       *openAPIOperation   `json:"post"`
+	Delete     *openAPIOperation   `json:"delete"`
+	Options    *openAPIOperation   `json:"options"`
+	Head       *openAPIOperation   `json:"head"`
+	Patch      *openAPIOperation   `json:"patch"`
+	Trace      *openAPIOperation   `json:"trace"`
+}
+
+// operations returns the operations of the path item by method
+func (p openAPIPathItem) operations() map[string]*openAPIOperation {
+	operations := map[string]*openAPIOperation{}
+	for method, operation := range map[string]*openAPIOperation{
+		http.MethodGet: p.Get, http.MethodPut: p.Put, http.MethodPost: p.Post, http.MethodDelete: p.Delete,
+		http.MethodOptions: p.Options, http.MethodHead: p.Head, http.MethodPatch: p.Patch, http.MethodTrace: p.Trace,
+	} {
+		if operation != nil {
+			operations[method] = operation
+		}
+	}
+	return operations
+}
+
+type openAPIOperation struct {
+	OperationID string              `json:"operationId"`
+	Parameters  []*openAPIParameter `json:"parameters"`
+}
+
+// openAPIParameter is a parameter object or a reference to a parameter object
+type openAPIParameter struct {
+	Ref string `json:"$ref"`
+	Parameter
+}
+
+// OpenAPIValidator validates path, query, header and cookie params of requests against the
+// operations of an OpenAPI 3 document. It is safe for concurrent use
+type OpenAPIValidator struct {
+	opts options
+	// prefixes are the paths of the server urls, which prefix the operation paths
+	prefixes []string
+	routes   []*openAPIRoute
+}
+
+// openAPIRoute is a path of the document
+type openAPIRoute struct {
+	path       string
+	pattern    *regexp.Regexp
+	names      []string
+	operations map[string]*openAPIBinding
+}
+
+// openAPIBinding binds params of an operation into a struct type created from the parameter objects
+type openAPIBinding struct {
+	operationID string
+	typ         reflect.Type
+	params      []Parameter
+}
+
+// OperationParams are params of a request validated by an OpenAPIValidator
+type OperationParams struct {
+	// OperationID is the operationId of the matched operation
+	OperationID string
+	// Method is the method of the matched operation
+	Method string
+	// Path is the path template of the matched operation, e.g. /users/{id}
+	Path string
+	// Values are the converted values of present params and params having a default value,
+	// by location and param name
+	Values map[In]map[string]interface{}
+	// Warnings are raised by usages of deprecated params
+	Warnings []Warning
+}
+
+// NewOpenAPIValidator returns a validator of the operations of the OpenAPI 3 JSON document. Parameters
+// are bound using the same conversion rules as an Extractor created by the same options, and schema
+// types are converted into string, bool, int32, int64, float32, float64, uuid.UUID, slices,
+// map[string]interface{} values of objects and maps. Constraints of object properties are not validated,
+// same as nested fields of an Extractor, defaults of objects are not applied, and form params and request
+// bodies are not validated. Documents having defaults or enums invalid for their types fail
+func NewOpenAPIValidator(document []byte, opts ...Option) (*OpenAPIValidator, error) {
+	var doc openAPIDocument
+	err := json.Unmarshal(document, &doc)
+	if err != nil {
+		return nil, fmt.Errorf(`invalid OpenAPI document due to %v`, err)
+	}
+
+	v := &OpenAPIValidator{opts: newOptions(opts)}
+	for _, server := range doc.Servers {
+		u, err := url.Parse(server.URL)
+		if err == nil && strings.Trim(u.Path, `/`) != `` {
+			v.prefixes = append(v.prefixes, strings.TrimSuffix(u.Path, `/`))
+		}
+	}
+
+	b := openAPIBuilder{doc: doc, opts: v.opts}
+	for path, item := range doc.Paths {
+		pattern, names := pathPattern(path)
+		route := &openAPIRoute{path: path, pattern: pattern, names: names, operations: map[string]*openAPIBinding{}}
+		for method, operation := range item.operations() {
+			binding, err := b.binding(operation.OperationID, item.Parameters, operation.Parameters)
+			if err != nil {
+				return nil, fmt.Errorf(`invalid operation %v %v due to %v`, method, path, err)
+			}
+			route.operations[method] = binding
+		}
+		v.routes = append(v.routes, route)
+	}
+
+	// paths without templates match before templated paths, e.g. /users/me before /users/{id}
+	sort.Slice(v.routes, func(i, j int) bool {
+		ti, tj := strings.Count(v.routes[i].path, `{`), strings.Count(v.routes[j].path, `{`)
+		if ti != tj {
+			return ti < tj
+		}
+		return v.routes[i].path < v.routes[j].path
+	})
+	return v, nil
+}
+
+// pathPattern returns a regular expression matching escaped paths of the path template and the names
+// of its {name} segments, whose values are captured by the groups of the expression in the same order
+func pathPattern(path string) (*regexp.Regexp, []string) {
+	var b strings.Builder
+	var names []string
+	b.WriteString(`^`)
+	for {
+		start := strings.IndexByte(path, '{')
+		end := strings.IndexByte(path, '}')
+		if start < 0 || end < start {
+			break
+		}
+		b.WriteString(regexp.QuoteMeta(path[:start]))
+		b.WriteString(`([^/]+)`)
+		names = append(names, path[start+1:end])
+		path = path[end+1:]
+	}
+	b.WriteString(regexp.QuoteMeta(path) + `$`)
+	return regexp.MustCompile(b.String()), names
+}
+
+// Validate binds and validates params of req against the operation matching its path and method.
+// Requests not matching any operation fail with ErrorUnknownOperation, and invalid params fail with
+// the same errors as an Extractor, which are aggregated when WithErrorAggregation is set
+func (v *OpenAPIValidator) Validate(req *http.Request) (*OperationParams, error) {
+	route, values := v.match(req)
+	if route == nil {
+		return nil, v.opts.withStatus(ErrorUnknownOperation{
+			error: fmt.Errorf(`no operation matches path %v`, req.URL.Path)})
+	}
+	binding, ok := route.operations[req.Method]
+	if !ok {
+		allowed := make([]string, 0, len(route.operations))
+		for method := range route.operations {
+			allowed = append(allowed, method)
+		}
+		sort.Strings(allowed)
+		return nil, v.opts.withStatus(ErrorUnknownOperation{
+			error:   fmt.Errorf(`method %v is not allowed for path %v`, req.Method, route.path),
+			Allowed: allowed,
+		})
+	}
+
+	o := v.opts
+	o.pathValue = func(_ *http.Request, name string) string {
+		return values[name]
+	}
+	value := reflect.New(binding.typ)
+	bound, warnings, err := extractor{opts: o}.bindDynamic(value, req, []In{InPath, InQuery, InHeader, InCookie})
+	if err != nil {
+		return nil, err
+	}
+
+	params := &OperationParams{
+		OperationID: binding.operationID,
+		Method:      req.Method,
+		Path:        route.path,
+		Values:      map[In]map[string]interface{}{},
+		Warnings:    warnings,
+	}
+
+	for i, prm := range binding.params {
+		field := binding.typ.Field(i)
+		if !bound[field.Name] && prm.Schema.Default == nil {
+			continue
+		}
+		if params.Values[prm.In] == nil {
+			params.Values[prm.In] = map[string]interface{}{}
+		}
+		params.Values[prm.In][prm.Name] = o.plain(value.Elem().Field(i))
+	}
+	return params, nil
+}
+
+// match returns the route matching the path of req and the values of its path params
+func (v *OpenAPIValidator) match(req *http.Request) (*openAPIRoute, map[string]string) {
+	path := req.URL.EscapedPath()
+	paths := []string{path}
+	for _, prefix := range v.prefixes {
+		if strings.HasPrefix(path, prefix+`/`) {
+			paths = append(paths, strings.TrimPrefix(path, prefix))
+		}
+	}
+
+	for _, p := range paths {
+		for _, route := range v.routes {
+			matches := route.pattern.FindStringSubmatch(p)
+			if matches == nil {
+				continue
+			}
+			values := map[string]string{}
+			for i, name := range route.names {
+				value, err := url.PathUnescape(matches[i+1])
+				if err != nil {
+					value = matches[i+1]
+				}
+				values[name] = value
+			}
+			return route, values
+		}
+	}
+	return nil, nil
+}
+
+// openAPIBuilder creates struct types binding parameters of operations
+type openAPIBuilder struct {
+	doc  openAPIDocument
+	opts options
+}
+
+// binding returns the binding of an operation having the parameters of its path item and its own
+// parameters, which override parameters of the path item having the same name and location
+func (b openAPIBuilder) binding(operationID string, common, own []*openAPIParameter) (*openAPIBinding, error) {
+	binding := &openAPIBinding{operationID: operationID}
+	index := map[string]int{}
+	for _, p := range append(append([]*openAPIParameter{}, common...), own...) {
+		prm, err := b.parameter(p)
+		if err != nil {
+			return nil, err
+		}
+		if prm.In != InPath && prm.In != InQuery && prm.In != InHeader && prm.In != InCookie {
+			return nil, fmt.Errorf(`unsupported location "%v" of parameter "%v"`, prm.In, prm.Name)
+		}
+		key := string(prm.In) + `:` + prm.Name
+		if i, ok := index[key]; ok {
+			binding.params[i] = prm
+			continue
+		}
+		index[key] = len(binding.params)
+		binding.params = append(binding.params, prm)
+	}
+
+	fields := make([]reflect.StructField, 0, len(binding.params))
+	for i, prm := range binding.params {
+		t, err := b.goType(prm.Schema)
+		if err != nil {
+			return nil, fmt.Errorf(`parameter "%v" due to %v`, prm.Name, err)
+		}
+		fields = append(fields, b.opts.dynamicField(i, t, b.tag(prm)))
+	}
+	binding.typ = reflect.StructOf(fields)
+	for i, prm := range binding.params {
+		err := b.check(binding.typ.Field(i), prm.In)
+		if err != nil {
+			return nil, fmt.Errorf(`parameter "%v" due to %v`, prm.Name, err)
+		}
+	}
+	return binding, nil
+}
+
+// check checks the default value and the enum values of field against its type, so documents having
+// invalid values fail when they are loaded instead of failing every request
+func (b openAPIBuilder) check(field reflect.StructField, in In) error {
+	tag, _ := b.opts.fieldTag(field)
+	c, err := tag.constraints()
+	if err != nil {
+		return err
+	}
+	prm := param{field: field, key: tag.keys()[0], keys: tag.keys(), in: in, tag: tag}
+	p := extractor{opts: b.opts}
+
+	if c.hasDefault {
+		value := reflect.New(field.Type).Elem()
+		err = p.absent(value, prm)
+		if err == nil {
+			err = p.validate(value, prm)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	t := field.Type
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	conv, ok := b.opts.converter(t)
+	if !ok {
+		return nil
+	}
+	for _, str := range c.enum {
+		_, err = conv.convertTo(prm, t, str)
+		if err != nil {
+			return fmt.Errorf(`invalid enum value "%v" due to %v`, str, err)
+		}
+	}
+	return nil
+}
+
+// parameter resolves the reference and the schema of p
+func (b openAPIBuilder) parameter(p *openAPIParameter) (Parameter, error) {
+	for i := 0; p.Ref != ``; i++ {
+		name := strings.TrimPrefix(p.Ref, `#/components/parameters/`)
+		resolved, ok := b.doc.Components.Parameters[name]
+		if !ok || name == p.Ref || i > maxRefs {
+			return Parameter{}, fmt.Errorf(`unresolvable parameter reference "%v"`, p.Ref)
+		}
+		p = resolved
+	}
+
+	prm := p.Parameter
+	schema, err := b.resolve(prm.Schema)
+	if err != nil {
+		return Parameter{}, err
+	}
+	prm.Schema = schema
+	return prm, nil
+}
+
+// maxRefs limits the number of references followed to resolve a schema, which prevents reference cycles
+const maxRefs = 32
+
+// resolve returns a copy of s having resolved references, or a string schema when s is nil.
+// Items of arrays without an items schema are strings
+func (b openAPIBuilder) resolve(s *Schema) (*Schema, error) {
+	return b.resolveDepth(s, 0)
+}
+
+func (b openAPIBuilder) resolveDepth(s *Schema, depth int) (*Schema, error) {
+	if s == nil {
+		return &Schema{Type: `string`}, nil
+	}
+	for s.Ref != `` {
+		name := strings.TrimPrefix(s.Ref, `#/components/schemas/`)
+		resolved, ok := b.doc.Components.Schemas[name]
+		depth++
+		if !ok || name == s.Ref || depth > maxRefs {
+			return nil, fmt.Errorf(`unresolvable schema reference "%v"`, s.Ref)
+		}
+		s = resolved
+	}
+
+	resolved := *s
+	var err error
+	if s.Items != nil || s.Type == `array` {
+		resolved.Items, err = b.resolveDepth(s.Items, depth+1)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(s.Properties) > 0 {
+		resolved.Properties = map[string]*Schema{}
+		for name, property := range s.Properties {
+			resolved.Properties[name], err = b.resolveDepth(property, depth+1)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if s.AdditionalProperties != nil {
+		var additional *Schema
+		switch a := s.AdditionalProperties.(type) {
+		case bool:
+			resolved.AdditionalProperties = a
+		case *Schema:
+			additional = a
+		default:
+			encoded, _ := json.Marshal(a)
+			additional = &Schema{}
+			err = json.Unmarshal(encoded, additional)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if additional != nil {
+			resolved.AdditionalProperties, err = b.resolveDepth(additional, depth+1)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &resolved, nil
+}
+
+// goType returns the Go type of values described by s
+func (b openAPIBuilder) goType(s *Schema) (reflect.Type, error) {
+	switch s.Type {
+	case `string`, ``:
+		if s.Format == `uuid` {
+			return uuidType, nil
+		}
+		return stringType, nil
+	case `boolean`:
+		return boolType, nil
+	case `integer`:
+		if s.Format == `int32` {
+			return int32Type, nil
+		}
+		return int64Type, nil
+	case `number`:
+		if s.Format == `float` {
+			return float32Type, nil
+		}
+		return float64Type, nil
+	case `array`:
+		items, err := b.goType(s.Items)
+		if err != nil {
+			return nil, err
+		}
+		return reflect.SliceOf(items), nil
+	case `object`:
+		if len(s.Properties) == 0 {
+			values := stringType
+			if additional, ok := s.AdditionalProperties.(*Schema); ok {
+				var err error
+				values, err = b.goType(additional)
+				if err != nil {
+					return nil, err
+				}
+			}
+			return reflect.MapOf(stringType, values), nil
+		}
+
+		required := map[string]bool{}
+		for _, name := range s.Required {
+			required[name] = true
+		}
+		names := make([]string, 0, len(s.Properties))
+		for name := range s.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fields := make([]reflect.StructField, 0, len(names))
+		for i, name := range names {
+			t, err := b.goType(s.Properties[name])
+			if err != nil {
+				return nil, err
+			}
+			// properties are pointers, which are set only for present properties
+			prm := Parameter{Name: name, Required: required[name], Schema: s.Properties[name]}
+			fields = append(fields, b.opts.dynamicField(i, reflect.PtrTo(t), b.tag(prm)))
+		}
+		return reflect.StructOf(fields), nil
+	default:
+		return nil, fmt.Errorf(`unsupported schema type "%v"`, s.Type)
+	}
+}
+
+// tag returns the field tag of prm, having the location, style and constraints of the parameter object
+func (b openAPIBuilder) tag(prm Parameter) string {
+	parts := []string{escapeTag(prm.Name, ',')}
+	if prm.In != `` {
+		style := prm.Style
+		if style == `` {
+			style = styles[prm.In][0]
+		}
+		parts = append(parts, `in=`+string(prm.In), `style=`+style)
+		if prm.Explode != nil {
+			parts = append(parts, `explode=`+strconv.FormatBool(*prm.Explode))
+		}
+	}
+	if prm.Required {
+		parts = append(parts, `required`)
+	}
+	if prm.Deprecated {
+		parts = append(parts, `deprecated`)
+	}
+
+	s := prm.Schema
+	if s.Type == `array` && s.Items != nil {
+		if s.MinItems != nil {
+			parts = append(parts, `minItems=`+strconv.Itoa(*s.MinItems))
+		}
+		if s.MaxItems != nil {
+			parts = append(parts, `maxItems=`+strconv.Itoa(*s.MaxItems))
+		}
+		if values, ok := s.Default.([]interface{}); ok {
+			parts = append(parts, `default=`+joinValues(values))
+		}
+		s = s.Items
+	} else if s.Default != nil && s.Type != `object` {
+		parts = append(parts, `default=`+joinValues([]interface{}{s.Default}))
+	}
+
+	if len(s.Enum) > 0 {
+		parts = append(parts, `enum=`+joinValues(s.Enum))
+	}
+	if s.Minimum != nil {
+		parts = append(parts, `min=`+strconv.FormatFloat(*s.Minimum, 'f', -1, 64))
+	}
+	if s.Maximum != nil {
+		parts = append(parts, `max=`+strconv.FormatFloat(*s.Maximum, 'f', -1, 64))
+	}
+	if s.MinLength != nil {
+		parts = append(parts, `minLength=`+strconv.Itoa(*s.MinLength))
+	}
+	if s.MaxLength != nil {
+		parts = append(parts, `maxLength=`+strconv.Itoa(*s.MaxLength))
+	}
+	if s.Pattern != `` {
+		parts = append(parts, `pattern=`+escapeTag(s.Pattern, ','))
+	}
+	return strings.Join(parts, `,`)
+}
+
+// joinValues joins JSON values separated by |, escaping separators of the values. Numbers are formatted
+// without exponents, e.g. 1000000 instead of 1e+06, so they are converted into integers
+func joinValues(values []interface{}) string {
+	strs := make([]string, 0, len(values))
+	for _, value := range values {
+		str := fmt.Sprint(value)
+		if n, ok := value.(float64); ok {
+			str = strconv.FormatFloat(n, 'f', -1, 64)
+		}
+		strs = append(strs, escapeTag(escapeTag(str, '|'), ','))
+	}
+	return strings.Join(strs, `|`)
+}
+
+// escapeTag escapes sep of str by a backslash, which is the inverse of splitEscaped
+func escapeTag(str string, sep byte) string {
+	return strings.ReplaceAll(str, string(sep), `\`+string(sep))
+}
@@ -0,0 +1,239 @@
+package paramex
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const openAPIDocumentJSON = `{
+  "openapi": "3.0.3",
+  "servers": [{"url": "https://api.nipuna.lk/v1"}],
+  "paths": {
+    "/users/{id}": {
+      "parameters": [
+        {"name": "id", "in": "path", "required": true, "schema": {"type": "integer", "format": "int32", "minimum": 1}}
+      ],
+      "get": {
+        "operationId": "getUser",
+        "parameters": [
+          {"$ref": "#/components/parameters/Fields"},
+          {"name": "sort", "in": "query", "schema": {"type": "string", "enum": ["asc", "desc"], "default": "asc"}},
+          {"name": "filter", "in": "query", "style": "deepObject", "explode": true, "schema": {"$ref": "#/components/schemas/Filter"}},
+          {"name": "X-Request-Id", "in": "header", "required": true, "schema": {"type": "string", "format": "uuid"}},
+          {"name": "session", "in": "cookie", "schema": {"type": "string", "pattern": "^[a-z]{2,}$"}},
+          {"name": "legacy", "in": "query", "deprecated": true, "schema": {"type": "boolean"}}
+        ]
+      },
+      "delete": {"operationId": "deleteUser"}
+    },
+    "/users/me": {
+      "get": {"operationId": "getMe"}
+    }
+  },
+  "components": {
+    "parameters": {
+      "Fields": {"name": "fields", "in": "query", "explode": false, "schema": {"type": "array", "maxItems": 3, "items": {"type": "string"}}}
+    },
+    "schemas": {
+      "Filter": {
+        "type": "object",
+        "required": ["owner"],
+        "properties": {
+          "owner": {"type": "integer"},
+          "status": {"type": "string", "enum": ["open", "closed"]}
+        }
+      }
+    }
+  }
+}`
+
+func TestOpenAPIValidator(t *testing.T) {
+	validator, err := NewOpenAPIValidator([]byte(openAPIDocumentJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run(`test valid request`, func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet,
+			`https://api.nipuna.lk/v1/users/42?fields=name,email&filter[owner]=7&filter[status]=open&legacy=true`, nil)
+		req.Header.Set(`X-Request-Id`, `5d1c4b7a-2b59-4a53-9d34-0f3f4a8b6c10`)
+		req.AddCookie(&http.Cookie{Name: `session`, Value: `abc`})
+
+		params, err := validator.Validate(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if params.OperationID != `getUser` || params.Path != `/users/{id}` || params.Method != http.MethodGet {
+			t.Errorf(`unexpected operation %v %v %v`, params.OperationID, params.Method, params.Path)
+		}
+		if len(params.Warnings) != 1 {
+			t.Errorf(`expected a warning of the deprecated param, but received %v`, params.Warnings)
+		}
+
+		expected := map[In]map[string]interface{}{
+			InPath: {`id`: int32(42)},
+			InQuery: {
+				`fields`: []string{`name`, `email`},
+				`sort`:   `asc`,
+				`filter`: map[string]interface{}{`owner`: int64(7), `status`: `open`},
+				`legacy`: true,
+			},
+			InCookie: {`session`: `abc`},
+		}
+		id := params.Values[InHeader][`X-Request-Id`]
+		delete(params.Values, InHeader)
+		if id == nil {
+			t.Errorf(`expected header X-Request-Id`)
+		}
+		if !reflect.DeepEqual(params.Values, expected) {
+			t.Errorf(`expected values %v, but received %v`, expected, params.Values)
+		}
+	})
+
+	t.Run(`test zero properties`, func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, `https://api.nipuna.lk/v1/users/42?filter[owner]=0`, nil)
+		req.Header.Set(`X-Request-Id`, `5d1c4b7a-2b59-4a53-9d34-0f3f4a8b6c10`)
+		params, err := validator.Validate(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := map[string]interface{}{`owner`: int64(0)}
+		if !reflect.DeepEqual(params.Values[InQuery][`filter`], expected) {
+			t.Errorf(`expected filter %v, but received %v`, expected, params.Values[InQuery][`filter`])
+		}
+	})
+
+	t.Run(`test invalid params`, func(t *testing.T) {
+		tests := []struct {
+			target string
+			header string
+			err    interface{}
+		}{
+			{`/users/0`, `5d1c4b7a-2b59-4a53-9d34-0f3f4a8b6c10`, ErrorInvalidValue{}},
+			{`/users/abc`, `5d1c4b7a-2b59-4a53-9d34-0f3f4a8b6c10`, ErrorUnmarshalType{}},
+			{`/users/1`, ``, ErrorRequiredParam{}},
+			{`/users/1?sort=up`, `5d1c4b7a-2b59-4a53-9d34-0f3f4a8b6c10`, ErrorInvalidValue{}},
+			{`/users/1?fields=a,b,c,d`, `5d1c4b7a-2b59-4a53-9d34-0f3f4a8b6c10`, ErrorInvalidValue{}},
+		}
+		for _, test := range tests {
+			req, _ := http.NewRequest(http.MethodGet, `https://api.nipuna.lk/v1`+test.target, nil)
+			if test.header != `` {
+				req.Header.Set(`X-Request-Id`, test.header)
+			}
+			_, err := validator.Validate(req)
+			if reflect.TypeOf(err) != reflect.TypeOf(test.err) {
+				t.Errorf(`%v: expected %T, but received %v`, test.target, test.err, err)
+			}
+		}
+	})
+
+	t.Run(`test unknown operations`, func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, `https://api.nipuna.lk/v1/orders/1`, nil)
+		_, err := validator.Validate(req)
+		var unknown ErrorUnknownOperation
+		if !errors.As(err, &unknown) || unknown.StatusCode() != http.StatusNotFound {
+			t.Errorf(`expected "ErrorUnknownOperation" with status 404, but received %v`, err)
+		}
+
+		req, _ = http.NewRequest(http.MethodPost, `https://api.nipuna.lk/v1/users/1`, nil)
+		_, err = validator.Validate(req)
+		if !errors.As(err, &unknown) || unknown.StatusCode() != http.StatusMethodNotAllowed ||
+			!reflect.DeepEqual(unknown.Allowed, []string{http.MethodDelete, http.MethodGet}) {
+			t.Errorf(`expected "ErrorUnknownOperation" with status 405, but received %v`, err)
+		}
+	})
+
+	t.Run(`test concrete paths`, func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, `https://api.nipuna.lk/users/me`, nil)
+		params, err := validator.Validate(req)
+		if err != nil || params.OperationID != `getMe` {
+			t.Errorf(`expected operation getMe, but received %v %v`, params, err)
+		}
+	})
+
+	t.Run(`test error aggregation`, func(t *testing.T) {
+		validator, err := NewOpenAPIValidator([]byte(openAPIDocumentJSON), WithErrorAggregation())
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, _ := http.NewRequest(http.MethodGet, `https://api.nipuna.lk/v1/users/0?sort=up`, nil)
+		_, err = validator.Validate(req)
+		if errs, ok := err.(Errors); !ok || len(errs) != 3 {
+			t.Errorf(`expected 3 aggregated errors, but received %v`, err)
+		}
+	})
+
+	t.Run(`test large numbers`, func(t *testing.T) {
+		validator, err := NewOpenAPIValidator([]byte(`{"paths": {"/a": {"get": {"parameters": [
+			{"name": "limit", "in": "query", "schema": {"type": "integer", "default": 1000000, "maximum": 2000000}},
+			{"name": "size", "in": "query", "schema": {"type": "integer", "enum": [1000000, 2000000]}}
+		]}}}}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, _ := http.NewRequest(http.MethodGet, `https://api.nipuna.lk/a?size=2000000`, nil)
+		params, err := validator.Validate(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := map[string]interface{}{`limit`: int64(1000000), `size`: int64(2000000)}
+		if !reflect.DeepEqual(params.Values[InQuery], expected) {
+			t.Errorf(`expected values %v, but received %v`, expected, params.Values[InQuery])
+		}
+	})
+
+	t.Run(`test arrays without items`, func(t *testing.T) {
+		validator, err := NewOpenAPIValidator([]byte(`{"paths": {"/a": {"get": {"parameters": [
+			{"name": "tags", "in": "query", "schema": {"type": "array"}}
+		]}}}}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, _ := http.NewRequest(http.MethodGet, `https://api.nipuna.lk/a?tags=a&tags=1`, nil)
+		params, err := validator.Validate(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(params.Values[InQuery][`tags`], []string{`a`, `1`}) {
+			t.Errorf(`expected tags [a 1], but received %v`, params.Values[InQuery][`tags`])
+		}
+	})
+
+	t.Run(`test path param names`, func(t *testing.T) {
+		validator, err := NewOpenAPIValidator([]byte(`{"paths": {"/users/{user-id}/files/{file.name}": {"get": {"parameters": [
+			{"name": "user-id", "in": "path", "required": true, "schema": {"type": "integer"}},
+			{"name": "file.name", "in": "path", "required": true, "schema": {"type": "string"}}
+		]}}}}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, _ := http.NewRequest(http.MethodGet, `https://api.nipuna.lk/users/7/files/a%20b.txt`, nil)
+		params, err := validator.Validate(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := map[string]interface{}{`user-id`: int64(7), `file.name`: `a b.txt`}
+		if !reflect.DeepEqual(params.Values[InPath], expected) {
+			t.Errorf(`expected values %v, but received %v`, expected, params.Values[InPath])
+		}
+	})
+
+	t.Run(`test invalid documents`, func(t *testing.T) {
+		documents := []string{
+			`{"paths": [}`,
+			`{"paths": {"/a": {"get": {"parameters": [{"$ref": "#/components/parameters/Missing"}]}}}}`,
+			`{"paths": {"/a": {"get": {"parameters": [{"name": "a", "in": "query", "schema": {"type": "null"}}]}}}}`,
+			`{"paths": {"/a": {"get": {"parameters": [{"name": "a", "in": "body"}]}}}}`,
+			`{"paths": {"/a": {"get": {"parameters": [{"name": "a", "in": "query", "schema": {"type": "integer", "default": "many"}}]}}}}`,
+			`{"paths": {"/a": {"get": {"parameters": [{"name": "a", "in": "query", "schema": {"type": "integer", "default": 0, "minimum": 1}}]}}}}`,
+			`{"paths": {"/a": {"get": {"parameters": [{"name": "a", "in": "query", "schema": {"type": "integer", "enum": [1, "two"]}}]}}}}`,
+		}
+		for _, document := range documents {
+			if _, err := NewOpenAPIValidator([]byte(document)); err == nil {
+				t.Errorf(`%v: expected an error`, document)
+			}
+		}
+	})
+}
@@ -0,0 +1,303 @@
+package paramex
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+const (
+	// defaultMaxMemory is the memory limit of multipart/form-data bodies, same as net/http
+	defaultMaxMemory = 32 << 20
+	// defaultMaxBodySize is the size limit of decoded request bodies, except forms
+	defaultMaxBodySize = 10 << 20
+	defaultMaxDepth    = 5
+	defaultMaxIndex    = 100
+)
+
+// Option configures an Extractor created by NewParamExtractor.
+// Options are applied once, therefore a configured Extractor is safe for concurrent use
+type Option func(*options)
+
+type options struct {
+	tagName         string
+	normalizer      KeyNormalizer
+	naming          NamingStrategy
+	maxMemory       int64
+	aggregateErrors bool
+	strict          bool
+	allowedKeys     []string
+	multipleValues  MultipleValuesPolicy
+	maxDepth        int
+	maxIndex        int
+	converters      map[reflect.Type]converter
+	formatters      map[reflect.Type]FormatterFunc
+	statusCodes     map[reflect.Type]int
+	pathValue       PathValueFunc
+	envPrefix       string
+	// maxBodySize, disallowUnknownFields and useNumber configure decoding of JSON bodies
+	maxBodySize           int64
+	disallowUnknownFields bool
+	useNumber             bool
+	decoders              map[string]DecoderFunc
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		tagName:     `param`,
+		maxMemory:   defaultMaxMemory,
+		maxBodySize: defaultMaxBodySize,
+		maxDepth:    defaultMaxDepth,
+		maxIndex:    defaultMaxIndex,
+		pathValue:   (*http.Request).PathValue,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithTagName sets the struct tag used to annotate fields. Default tag is param
+func WithTagName(name string) Option {
+	return func(o *options) {
+		o.tagName = name
+	}
+}
+
+// KeyNormalizer returns the normalized form of a parameter key. Keys having
+// the same normalized form are considered equivalent
+type KeyNormalizer func(key string) string
+
+// FoldCase treats keys differing only in case as equivalent, e.g. userId and UserID
+func FoldCase(key string) string {
+	return strings.ToLower(key)
+}
+
+// FoldCaseAndSeparators treats keys differing only in case, - and _ as equivalent,
+// which makes camelCase, snake_case and kebab-case keys equivalent, e.g. userId, user_id and User-Id
+func FoldCaseAndSeparators(key string) string {
+	return strings.NewReplacer(`-`, ``, `_`, ``).Replace(strings.ToLower(key))
+}
+
+// WithCaseInsensitiveKeys matches url query and form keys case-insensitively.
+// Headers are always matched case-insensitively. Same as WithKeyNormalizer(FoldCase)
+func WithCaseInsensitiveKeys() Option {
+	return WithKeyNormalizer(FoldCase)
+}
+
+// WithKeyNormalizer matches url query and form keys having the same normalized form.
+// Extraction fails with ErrorAmbiguousKey when several request keys match a single field
+func WithKeyNormalizer(normalizer KeyNormalizer) Option {
+	return func(o *options) {
+		o.normalizer = normalizer
+	}
+}
+
+// WithNaming binds fields without a tag, or with a tag having an empty name such as
+// `param:",multi=error"`, to the key derived from the field name by naming.
+// Explicit tag names are preferred and `param:"-"` still excludes a field
+func WithNaming(naming NamingStrategy) Option {
+	return func(o *options) {
+		o.naming = naming
+	}
+}
+
+// WithMaxMemory sets the maximum bytes of a multipart/form-data body stored in memory,
+// remaining parts are stored in temporary files. Default is 32 MB
+func WithMaxMemory(n int64) Option {
+	return func(o *options) {
+		o.maxMemory = n
+	}
+}
+
+// WithErrorAggregation binds every parameter before returning an error and
+// returns all failures as Errors, instead of returning the first failure
+func WithErrorAggregation() Option {
+	return func(o *options) {
+		o.aggregateErrors = true
+	}
+}
+
+// WithConverter registers fn to convert parameter values into fields of the type of typ.
+// typ is usually a zero value, e.g. WithConverter(time.Time{}, parseTime).
+// Registered converters replace the built in converter of the same type and
+// are also used for elements of slice fields
+func WithConverter(typ interface{}, fn ConverterFunc) Option {
+	return func(o *options) {
+		t := reflect.TypeOf(typ)
+		if o.converters == nil {
+			o.converters = map[reflect.Type]converter{}
+		}
+		o.converters[t] = converter{name: fmt.Sprint(t), convert: fn}
+	}
+}
+
+// WithFormatter registers fn to format fields of the type of typ when encoding parameters,
+// which is the inverse of the converter registered with WithConverter,
+// e.g. WithFormatter(time.Time{}, formatTime)
+func WithFormatter(typ interface{}, fn FormatterFunc) Option {
+	return func(o *options) {
+		if o.formatters == nil {
+			o.formatters = map[reflect.Type]FormatterFunc{}
+		}
+		o.formatters[reflect.TypeOf(typ)] = fn
+	}
+}
+
+// WithMaxDepth sets the maximum number of brackets of a bracket notation key, such as
+// 2 of filter[owner][id]. Keys exceeding the limit fail with ErrorLimitExceeded. Default is 5
+func WithMaxDepth(n int) Option {
+	return func(o *options) {
+		o.maxDepth = n
+	}
+}
+
+// WithMaxIndex sets the maximum slice index of a bracket notation key, such as 1 of items[1][sku].
+// Since slices are allocated up to the index, the limit prevents memory exhaustion caused by
+// hostile indices. Keys exceeding the limit fail with ErrorLimitExceeded. Default is 100
+func WithMaxIndex(n int) Option {
+	return func(o *options) {
+		o.maxIndex = n
+	}
+}
+
+// PathValueFunc returns the value of the named path wildcard of req, or an empty string when it is absent
+type PathValueFunc func(req *http.Request, name string) string
+
+// WithPathValues sets the function looking up path params, for routers not setting path values
+// of http.Request, e.g. WithPathValues(func(req *http.Request, name string) string {
+// return chi.URLParam(req, name) }). Default is http.Request.PathValue
+func WithPathValues(fn PathValueFunc) Option {
+	return func(o *options) {
+		o.pathValue = fn
+	}
+}
+
+// WithMaxBodySize sets the size limit of request bodies decoded into body fields, except forms limited by
+// net/http and WithMaxMemory. Larger bodies fail with ErrorBodyTooLarge. Default is 10 MB
+func WithMaxBodySize(n int64) Option {
+	return func(o *options) {
+		o.maxBodySize = n
+	}
+}
+
+// WithDisallowUnknownFields fails decoding JSON bodies having fields not declared by the body type
+// with ErrorUnknownParams, same as json.Decoder.DisallowUnknownFields
+func WithDisallowUnknownFields() Option {
+	return func(o *options) {
+		o.disallowUnknownFields = true
+	}
+}
+
+// WithUseNumber decodes numbers of JSON bodies into interface{} values as json.Number instead of float64,
+// same as json.Decoder.UseNumber
+func WithUseNumber() Option {
+	return func(o *options) {
+		o.useNumber = true
+	}
+}
+
+// WithDecoder sets the decoder of request bodies of the media type, e.g. WithDecoder(`application/yaml`, fn),
+// which replaces the default decoder of the type
+func WithDecoder(mediaType string, fn DecoderFunc) Option {
+	return func(o *options) {
+		if o.decoders == nil {
+			o.decoders = map[string]DecoderFunc{}
+		}
+		o.decoders[strings.ToLower(mediaType)] = fn
+	}
+}
+
+// WithEnvPrefix sets the prefix of environment variables extracted by ExtractEnv, e.g. WithEnvPrefix(`APP_`)
+// binds `param:"PORT"` from APP_PORT
+func WithEnvPrefix(prefix string) Option {
+	return func(o *options) {
+		o.envPrefix = prefix
+	}
+}
+
+// WithStrict fails extraction of url queries and form values with ErrorUnknownParams
+// when the request has keys not declared by any field.
+//
+// allowed are keys passed through without being declared, such as tracking parameters.
+// They are matched using path.Match patterns, e.g. WithStrict(`utm_*`, `fbclid`)
+func WithStrict(allowed ...string) Option {
+	return func(o *options) {
+		o.strict = true
+		o.allowedKeys = append(o.allowedKeys, allowed...)
+	}
+}
+
+// MultipleValuesPolicy decides the value of a non slice field when a parameter has multiple values
+type MultipleValuesPolicy int
+
+// Multiple values policies, which can be set for every field using WithMultipleValues
+// or for a single field using the multi tag option, e.g. `param:"role,multi=error"`
+const (
+	// FirstValue uses the first value, which is the default policy (multi=first)
+	FirstValue MultipleValuesPolicy = iota
+	// LastValue uses the last value (multi=last)
+	LastValue
+	// RejectMultipleValues fails with ErrorMultipleValues (multi=error)
+	RejectMultipleValues
+)
+
+func parseMultipleValuesPolicy(name string) (MultipleValuesPolicy, error) {
+	switch name {
+	case `first`:
+		return FirstValue, nil
+	case `last`:
+		return LastValue, nil
+	case `error`:
+		return RejectMultipleValues, nil
+	default:
+		return FirstValue, fmt.Errorf(`invalid multiple values policy "%v", required first, last or error`, name)
+	}
+}
+
+// WithMultipleValues sets the policy of non slice fields receiving multiple values.
+// Since proxies and backends may disagree on which value wins, RejectMultipleValues
+// defends against http parameter pollution. Default policy is FirstValue
+func WithMultipleValues(policy MultipleValuesPolicy) Option {
+	return func(o *options) {
+		o.multipleValues = policy
+	}
+}
+
+// WithStatusCode overrides the status code reported by StatusCode() of errors
+// having the same type as err. err is usually a zero value of a paramex error
+// type, e.g. WithStatusCode(ErrorUnmarshalType{}, http.StatusUnprocessableEntity)
+func WithStatusCode(err error, code int) Option {
+	return func(o *options) {
+		if o.statusCodes == nil {
+			o.statusCodes = map[reflect.Type]int{}
+		}
+		o.statusCodes[reflect.TypeOf(err)] = code
+	}
+}
+
+// withStatus applies status codes configured with WithStatusCode to err
+func (o options) withStatus(err error) error {
+	if err == nil || len(o.statusCodes) == 0 {
+		return err
+	}
+
+	if errs, ok := err.(Errors); ok {
+		overridden := make(Errors, 0, len(errs))
+		for _, e := range errs {
+			overridden = append(overridden, o.withStatus(e))
+		}
+		return overridden
+	}
+
+	code, ok := o.statusCodes[reflect.TypeOf(err)]
+	if !ok {
+		return err
+	}
+	overrider, ok := err.(statusOverrider)
+	if !ok {
+		return err
+	}
+	return overrider.withStatus(code)
+}
@@ -0,0 +1,312 @@
+package paramex
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOptions(t *testing.T) {
+	t.Run(`test WithTagName`, func(t *testing.T) {
+		req, _ := http.NewRequest(`GET`, `https://nipuna.lk?name=query_name&age=20`, nil)
+		obj := struct {
+			Name string `query:"name"`
+			Age  int    `param:"age"`
+		}{}
+		err := NewParamExtractor(WithTagName(`query`)).ExtractQueries(&obj, req)
+		if err != nil {
+			t.Fatalf(`error extracting queries due to %v`, err)
+		}
+		if obj.Name != `query_name` || obj.Age != 0 {
+			t.Errorf(`expected [{query_name 0}], but received [%v]`, obj)
+		}
+	})
+
+	t.Run(`test WithCaseInsensitiveKeys`, func(t *testing.T) {
+		req, _ := http.NewRequest(`GET`, `https://nipuna.lk?Name=query_name&AGE=20`, nil)
+		obj := queryParams{}
+		err := NewParamExtractor(WithCaseInsensitiveKeys()).ExtractQueries(&obj, req)
+		if err != nil {
+			t.Fatalf(`error extracting queries due to %v`, err)
+		}
+		if obj.Name != `query_name` || obj.Age != 20 {
+			t.Errorf(`expected [{query_name 20}], but received [%v]`, obj)
+		}
+
+		obj = queryParams{}
+		_ = NewParamExtractor().ExtractQueries(&obj, req)
+		if obj.Name != `` {
+			t.Errorf(`expected "", but received %v`, obj.Name)
+		}
+	})
+
+	t.Run(`test WithKeyNormalizer`, func(t *testing.T) {
+		req, _ := http.NewRequest(`GET`, `https://nipuna.lk?User-Id=5&first_name=nipuna&lastName=senpathi`, nil)
+		obj := struct {
+			UserID    int    `param:"userid"`
+			FirstName string `param:"firstName"`
+			LastName  string `param:"last_name"`
+		}{}
+		err := NewParamExtractor(WithKeyNormalizer(FoldCaseAndSeparators), WithStrict()).ExtractQueries(&obj, req)
+		if err != nil {
+			t.Fatalf(`error extracting queries due to %v`, err)
+		}
+		if obj.UserID != 5 || obj.FirstName != `nipuna` || obj.LastName != `senpathi` {
+			t.Errorf(`expected [{5 nipuna senpathi}], but received [%v]`, obj)
+		}
+
+		req, _ = http.NewRequest(`GET`, `https://nipuna.lk?user_id=5&userId=6`, nil)
+		err = NewParamExtractor(WithKeyNormalizer(FoldCaseAndSeparators)).ExtractQueries(&obj, req)
+		ambiguousErr, ok := err.(ErrorAmbiguousKey)
+		if !ok {
+			t.Fatalf(`expected "ErrorAmbiguousKey", but received %v`, reflect.TypeOf(err))
+		}
+		if ambiguousErr.Key != `userid` || !reflect.DeepEqual(ambiguousErr.Keys, []string{`userId`, `user_id`}) {
+			t.Errorf(`unexpected error %+v`, ambiguousErr)
+		}
+		exErr := `ambiguous keys [userId, user_id] received for param "userid"`
+		if err.Error() != exErr {
+			t.Errorf(`expexted [%v], but received [%v]`, exErr, err.Error())
+		}
+	})
+
+	t.Run(`test WithNaming`, func(t *testing.T) {
+		req, _ := http.NewRequest(`GET`, `https://nipuna.lk?user_id=5&first_name=nipuna&age=20&height=1.7&role=a&role=b`, nil)
+		req.Header.Set(`X-Request-Id`, `req-1`)
+		obj := struct {
+			UserID     int
+			FirstName  string  `param:"-"`
+			Years      int     `param:"age"`
+			Height     float64 `param:""`
+			Role       string  `param:",multi=error"`
+			XRequestID string
+			internal   string
+		}{}
+		err := NewParamExtractor(WithNaming(SnakeCase)).ExtractQueries(&obj, req)
+		if _, ok := err.(ErrorMultipleValues); !ok {
+			t.Fatalf(`expected "ErrorMultipleValues", but received %v`, err)
+		}
+		if obj.UserID != 5 || obj.FirstName != `` || obj.Years != 20 || obj.Height != 1.7 || obj.internal != `` {
+			t.Errorf(`unexpected extracted values [%+v]`, obj)
+		}
+
+		err = NewParamExtractor(WithNaming(HeaderCase)).ExtractHeaders(&obj, req)
+		if err != nil {
+			t.Fatalf(`error extracting headers due to %v`, err)
+		}
+		if obj.XRequestID != `req-1` {
+			t.Errorf(`expected [req-1], but received [%v]`, obj.XRequestID)
+		}
+	})
+
+	t.Run(`test WithMaxMemory`, func(t *testing.T) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		_ = writer.WriteField(`name`, `multipart_name`)
+		_ = writer.WriteField(`age`, `50`)
+		_ = writer.Close()
+
+		req, _ := http.NewRequest(`POST`, `https://nipuna.lk`, body)
+		req.Header.Set(`Content-Type`, writer.FormDataContentType())
+		obj := formParams{}
+		err := NewParamExtractor(WithMaxMemory(1<<10)).ExtractForms(&obj, req)
+		if err != nil {
+			t.Fatalf(`error extracting forms due to %v`, err)
+		}
+		if obj.Name != `multipart_name` || obj.Age != 50 {
+			t.Errorf(`expected [{multipart_name 50}], but received [%v]`, obj)
+		}
+	})
+
+	t.Run(`test WithErrorAggregation`, func(t *testing.T) {
+		req, err := makeRequest()
+		if err != nil {
+			t.Fatal(`error creating request`, err)
+		}
+		obj := struct {
+			Name    int     `param:"name"`
+			Age     bool    `param:"age"`
+			Height  float64 `param:"height"`
+			Married bool    `param:"married"`
+		}{}
+		err = NewParamExtractor(WithErrorAggregation()).ExtractHeaders(&obj, req)
+		errs, ok := err.(Errors)
+		if !ok {
+			t.Fatalf(`expected "Errors", but received %v`, reflect.TypeOf(err))
+		}
+		if len(errs) != 2 {
+			t.Fatalf(`expected 2 errors, but received %v`, errs)
+		}
+		fieldErr := &FieldError{}
+		if !errors.As(errs[1], &fieldErr) || fieldErr.Field != `Age` {
+			t.Errorf(`expected error of "Age", but received %v`, errs[1])
+		}
+		if obj.Height != 1.74 || !obj.Married {
+			t.Errorf(`expected valid params to be extracted, but received [%v]`, obj)
+		}
+	})
+
+	t.Run(`test WithConverter`, func(t *testing.T) {
+		req, _ := http.NewRequest(`GET`, `https://nipuna.lk?from=2021-05-01&to=2021-05-02&to=2021-05-03&name=Test`, nil)
+		obj := struct {
+			From time.Time   `param:"from"`
+			To   []time.Time `param:"to"`
+			Name string      `param:"name"`
+		}{}
+		extractor := NewParamExtractor(
+			WithConverter(time.Time{}, func(value string) (interface{}, error) {
+				return time.Parse(`2006-01-02`, value)
+			}),
+			WithConverter(``, func(value string) (interface{}, error) {
+				return strings.ToLower(value), nil
+			}),
+		)
+		err := extractor.ExtractQueries(&obj, req)
+		if err != nil {
+			t.Fatalf(`error extracting queries due to %v`, err)
+		}
+		if obj.From.Day() != 1 || len(obj.To) != 2 || obj.To[1].Day() != 3 || obj.Name != `test` {
+			t.Errorf(`unexpected extracted values [%v]`, obj)
+		}
+
+		req, _ = http.NewRequest(`GET`, `https://nipuna.lk?from=yesterday`, nil)
+		err = extractor.ExtractQueries(&obj, req)
+		if _, ok := err.(ErrorUnmarshalType); !ok {
+			t.Fatalf(`expected "ErrorUnmarshalType", but received %v`, reflect.TypeOf(err))
+		}
+		exErr := `error unmarshalling [yesterday] into [time.Time] due to parsing time "yesterday" as "2006-01-02": cannot parse "yesterday" as "2006"`
+		if err.Error() != exErr {
+			t.Errorf(`expexted [%v], but received [%v]`, exErr, err.Error())
+		}
+	})
+
+	t.Run(`test WithStrict`, func(t *testing.T) {
+		req, _ := http.NewRequest(`GET`, `https://nipuna.lk?name=query_name&limt=10&age=20&offest=5`, nil)
+		obj := queryParams{}
+		err := NewParamExtractor(WithStrict()).ExtractQueries(&obj, req)
+		unknownErr, ok := err.(ErrorUnknownParams)
+		if !ok {
+			t.Fatalf(`expected "ErrorUnknownParams", but received %v`, reflect.TypeOf(err))
+		}
+		if !reflect.DeepEqual(unknownErr.Keys, []string{`limt`, `offest`}) {
+			t.Errorf(`expected [limt offest], but received %v`, unknownErr.Keys)
+		}
+		if unknownErr.StatusCode() != http.StatusBadRequest {
+			t.Errorf(`expected [%d], but received [%d]`, http.StatusBadRequest, unknownErr.StatusCode())
+		}
+
+		req, _ = http.NewRequest(`GET`, `https://nipuna.lk?name=query_name&age=20`, nil)
+		req.Header.Set(`Limt`, `10`)
+		err = NewParamExtractor(WithStrict()).ExtractQueries(&obj, req)
+		if err != nil {
+			t.Errorf(`error extracting queries due to %v`, err)
+		}
+		err = NewParamExtractor(WithStrict()).ExtractHeaders(&obj, req)
+		if err != nil {
+			t.Errorf(`error extracting headers due to %v`, err)
+		}
+	})
+
+	t.Run(`test WithStrict allowed keys`, func(t *testing.T) {
+		req, _ := http.NewRequest(`POST`, `https://nipuna.lk`,
+			strings.NewReader(`name=form_name&UTM_source=mail&utm_medium=web&fbclid=1&limt=10&agee=5`))
+		req.Header.Set(`Content-Type`, `application/x-www-form-urlencoded`)
+
+		extractor := NewParamExtractor(WithStrict(`utm_*`, `fbclid`), WithErrorAggregation())
+		err := extractor.ExtractForms(&formParams{}, req)
+		errs, ok := err.(Errors)
+		if !ok || len(errs) != 1 {
+			t.Fatalf(`expected 1 error, but received %v`, err)
+		}
+		unknownErr, ok := errs[0].(ErrorUnknownParams)
+		if !ok {
+			t.Fatalf(`expected "ErrorUnknownParams", but received %v`, reflect.TypeOf(errs[0]))
+		}
+		if !reflect.DeepEqual(unknownErr.Keys, []string{`UTM_source`, `agee`, `limt`}) || unknownErr.In != InForm {
+			t.Errorf(`expected form keys [UTM_source agee limt], but received %v %v`, unknownErr.In, unknownErr.Keys)
+		}
+		if err.Error() != `unknown form params UTM_source, agee, limt` {
+			t.Errorf(`unexpected error message [%v]`, err.Error())
+		}
+
+		problem := NewProblem(err)
+		if len(problem.InvalidParams) != 3 || problem.InvalidParams[2].Name != `limt` {
+			t.Errorf(`unexpected invalid params %+v`, problem.InvalidParams)
+		}
+
+		req, _ = http.NewRequest(`GET`, `https://nipuna.lk?NAME=query_name&UTM_source=mail`, nil)
+		err = NewParamExtractor(WithStrict(`utm_*`), WithCaseInsensitiveKeys()).ExtractQueries(&queryParams{}, req)
+		if err != nil {
+			t.Errorf(`error extracting queries due to %v`, err)
+		}
+	})
+
+	t.Run(`test WithMultipleValues`, func(t *testing.T) {
+		req, _ := http.NewRequest(`GET`, `https://nipuna.lk?name=user&name=admin&age=20&age=30&height=1.7&height=1.8`, nil)
+		obj := struct {
+			Name   string  `param:"name"`
+			Age    int     `param:"age,multi=first"`
+			Height float64 `param:"height,multi=error"`
+		}{}
+
+		err := NewParamExtractor(WithMultipleValues(LastValue)).ExtractQueries(&obj, req)
+		multiErr, ok := err.(ErrorMultipleValues)
+		if !ok {
+			t.Fatalf(`expected "ErrorMultipleValues", but received %v`, reflect.TypeOf(err))
+		}
+		if multiErr.Key != `height` || multiErr.In != InQuery || !reflect.DeepEqual(multiErr.Values, []string{`1.7`, `1.8`}) {
+			t.Errorf(`unexpected error %+v`, multiErr)
+		}
+		if obj.Name != `admin` || obj.Age != 20 {
+			t.Errorf(`expected [{admin 20 0}], but received [%v]`, obj)
+		}
+
+		err = NewParamExtractor(WithMultipleValues(RejectMultipleValues)).ExtractQueries(&obj, req)
+		multiErr, ok = err.(ErrorMultipleValues)
+		if !ok || multiErr.Key != `name` {
+			t.Fatalf(`expected "ErrorMultipleValues" of name, but received %v`, err)
+		}
+		exErr := `multiple values [user, admin] received for single value param "name"`
+		if err.Error() != exErr {
+			t.Errorf(`expexted [%v], but received [%v]`, exErr, err.Error())
+		}
+		problem := NewProblem(err)
+		if problem.Status != http.StatusBadRequest || len(problem.InvalidParams) != 1 {
+			t.Errorf(`unexpected problem %+v`, problem)
+		}
+
+		req.Header.Add(`Role`, `user`)
+		req.Header.Add(`Role`, `admin`)
+		role := struct {
+			Role string `param:"role,multi=unknown"`
+		}{}
+		err = NewParamExtractor().ExtractHeaders(&role, req)
+		if _, ok := err.(ErrorUnSupportedParamType); !ok {
+			t.Errorf(`expected "ErrorUnSupportedParamType", but received %v`, reflect.TypeOf(err))
+		}
+	})
+
+	t.Run(`test concurrent use`, func(t *testing.T) {
+		extractor := NewParamExtractor(WithCaseInsensitiveKeys(), WithErrorAggregation(), WithStrict())
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				req, _ := http.NewRequest(`GET`, `https://nipuna.lk?NAME=query_name&age=20`, nil)
+				obj := queryParams{}
+				err := extractor.ExtractQueries(&obj, req)
+				if err != nil || obj.Name != `query_name` {
+					t.Errorf(`unexpected result [%v], %v`, obj, err)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
@@ -3,25 +3,41 @@ package paramex
 import (
 	"errors"
 	"fmt"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"path"
 	"reflect"
+	"sort"
 	"strconv"
-
-	"github.com/google/uuid"
+	"strings"
 )
 
-type extractorFunc func(key string, array bool) (interface{}, bool)
+// In is the location of a request where a parameter is read from
+type In string
 
+// Parameter locations
 const (
-	stringType  = string("")
-	boolType    = true
-	int32Type   = int32(0)
-	intType     = int(0)
-	int64Type   = int64(0)
-	float32Type = float32(0)
-	float64Type = float64(0)
+	InHeader In = `header`
+	InQuery  In = `query`
+	InForm   In = `form`
+	InPath   In = `path`
+	InCookie In = `cookie`
+	// InEnv is the location of environment variables bound by ExtractEnv
+	InEnv In = `env`
+	// InFlag is the location of command line flags bound by RegisterFlags
+	InFlag In = `flag`
+	// InBody is the location of the request body, which is bound to a field having the body tag option,
+	// e.g. `param:",body"`, or to a field named Body
+	InBody In = `body`
 )
 
+// keyed reports whether parameters of the location are url encoded key value pairs,
+// which may use bracket notation keys and are checked by strict mode
+func (in In) keyed() bool {
+	return in == InQuery || in == InForm
+}
+
 // The Extractor interface is implemented to extract http request headers, form values
 // and url query values
 type Extractor interface {
@@ -38,171 +54,612 @@ type Extractor interface {
 	ExtractForms(v interface{}, req *http.Request) error
 }
 
-type extractor struct{}
+// The ParamExtractor interface extends Extractor to extract every parameter location, request bodies,
+// responses and other sources. Extractors returned by NewParamExtractor implement ParamExtractor
+type ParamExtractor interface {
+	Extractor
+
+	// ExtractPath extract path params from sent request and binds to v
+	// `v` should be a Go struct reference
+	ExtractPath(v interface{}, req *http.Request) error
+
+	// ExtractCookies extract cookies from sent request and binds to v
+	// `v` should be a Go struct reference
+	ExtractCookies(v interface{}, req *http.Request) error
+
+	// Bind extract http parameters of the in location from sent request and binds to v.
+	// The returned Result describes the bound parameters
+	// `v` should be a Go struct reference
+	Bind(v interface{}, req *http.Request, in In) (*Result, error)
+
+	// BindRequest extract http parameters of every location from sent request and binds to v.
+	// Fields having the in option are bound from their location, other fields from url queries
+	// and form values, and the request body is bound into the body field
+	// `v` should be a Go struct reference
+	BindRequest(v interface{}, req *http.Request) (*Result, error)
+
+	// ExtractFrom extract parameters from src and binds to v. Sources of HeaderSource are bound
+	// by the rules of headers, and other sources by the rules of url queries
+	// `v` should be a Go struct reference
+	ExtractFrom(v interface{}, src Source) error
+
+	// BindFrom extract parameters from src and binds to v by the rules of the in location.
+	// The returned Result describes the bound parameters
+	// `v` should be a Go struct reference
+	BindFrom(v interface{}, src Source, in In) (*Result, error)
+
+	// ExtractEnv extract environment variables having the prefix set by WithEnvPrefix and binds to v
+	// `v` should be a Go struct reference
+	ExtractEnv(v interface{}) error
+
+	// ExtractResponseHeaders extract http headers from received response and binds to v
+	// `v` should be a Go struct reference
+	ExtractResponseHeaders(v interface{}, res *http.Response) error
+
+	// ExtractResponseCookies extract cookies set by received response and binds to v
+	// `v` should be a Go struct reference
+	ExtractResponseCookies(v interface{}, res *http.Response) error
+
+	// ExtractJSON decodes the JSON request body into the body field of v
+	// `v` should be a Go struct reference
+	ExtractJSON(v interface{}, req *http.Request) error
+
+	// ExtractBody decodes the request body into the body field of v by the decoder of the request
+	// Content-Type. Unsupported content types fail with ErrorUnsupportedMediaType
+	// `v` should be a Go struct reference
+	ExtractBody(v interface{}, req *http.Request) error
+}
+
+// Result describes parameters bound by an Extractor
+type Result struct {
+	// Params are the bound parameters in the field order
+	Params []BoundParam
+	// Warnings are raised by bound parameters, such as usages of deprecated parameters
+	Warnings []Warning
+}
+
+// Warning is a non fatal issue of a bound parameter
+type Warning struct {
+	// Field is the name of the Go struct field
+	Field string
+	// Key is the key the value was read from
+	Key string
+	// In is the location of the parameter
+	In In
+	// Message describes the issue
+	Message string
+}
+
+// BoundParam is a parameter bound to a Go struct field
+type BoundParam struct {
+	// Field is the name of the Go struct field
+	Field string
+	// Key is the key the value was read from
+	Key string
+	// In is the location of the parameter
+	In In
+	// Alias reports whether Key is an alias, i.e. not the first key of the tag
+	Alias bool
+}
 
-// NewParamExtractor returns an Extractor which extract
+// SetWarningHeaders adds a Warning header for every warning of results to header,
+// along with a Deprecation header, so clients are notified about deprecated parameters
+func SetWarningHeaders(header http.Header, results ...*Result) {
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		for _, warning := range result.Warnings {
+			header.Set(`Deprecation`, `true`)
+			header.Add(`Warning`, `299 - `+strconv.Quote(warning.Message))
+		}
+	}
+}
+
+// Aliases returns params bound using an alias key, such as deprecated keys of an api migration
+func (r *Result) Aliases() []BoundParam {
+	var aliases []BoundParam
+	for _, prm := range r.Params {
+		if prm.Alias {
+			aliases = append(aliases, prm)
+		}
+	}
+	return aliases
+}
+
+type extractor struct {
+	opts options
+}
+
+// NewParamExtractor returns a ParamExtractor which extract
 // req.Header, req.FormValue, req.URL.Query values and
 // binds them to a Go struct
-func NewParamExtractor() Extractor {
-	return extractor{}
+func NewParamExtractor(opts ...Option) ParamExtractor {
+	return extractor{opts: newOptions(opts)}
 }
 
 // ExtractHeaders extract http headers from sent request and binds to v
 func (p extractor) ExtractHeaders(v interface{}, req *http.Request) error {
-	return p.extract(v, func(key string, array bool) (interface{}, bool) {
-		if array {
-			return nil, false
-		}
-		str := req.Header.Get(key)
-		if str == `` {
-			return str, false
-		}
-		return str, true
-	})
+	_, err := p.Bind(v, req, InHeader)
+	return err
 }
 
 // ExtractQueries extract http url parameters from sent request and binds to v
 func (p extractor) ExtractQueries(v interface{}, req *http.Request) error {
-	return p.extract(v, func(key string, array bool) (interface{}, bool) {
-		str := req.URL.Query()[key]
-		if len(str) == 0 {
-			return "", false
+	_, err := p.Bind(v, req, InQuery)
+	return err
+}
+
+// ExtractForms extract http form values from sent request and binds to v.
+// multipart/form-data bodies are parsed with the memory limit set by WithMaxMemory
+func (p extractor) ExtractForms(v interface{}, req *http.Request) error {
+	_, err := p.Bind(v, req, InForm)
+	return err
+}
+
+// ExtractPath extract path params from sent request and binds to v.
+// Path values are looked up using http.Request.PathValue, unless WithPathValues is set
+func (p extractor) ExtractPath(v interface{}, req *http.Request) error {
+	_, err := p.Bind(v, req, InPath)
+	return err
+}
+
+// ExtractCookies extract cookies from sent request and binds to v
+func (p extractor) ExtractCookies(v interface{}, req *http.Request) error {
+	_, err := p.Bind(v, req, InCookie)
+	return err
+}
+
+// Bind extract http parameters of the in location from sent request and binds to v
+func (p extractor) Bind(v interface{}, req *http.Request, in In) (*Result, error) {
+	if in == InBody {
+		result, err := p.bindBody(v, req, nil)
+		return result, p.opts.withStatus(err)
+	}
+	src, err := p.source(req, in)
+	if err != nil {
+		return nil, p.opts.withStatus(err)
+	}
+	return p.BindFrom(v, src, in)
+}
+
+// BindRequest extract http parameters of every location of sent request and binds to v. Fields having
+// the in option are bound from their location, other fields from url queries and form values, in order,
+// and then the request body is decoded into the body field. Defaults and required checks are applied
+// once, to fields absent in every location
+func (p extractor) BindRequest(v interface{}, req *http.Request) (*Result, error) {
+	result := &Result{}
+	var errs Errors
+	var absent []param
+	deferAbsent := func(_ reflect.Value, prm param) error {
+		absent = append(absent, prm)
+		return nil
+	}
+	for _, in := range []In{InPath, InQuery, InForm, InHeader, InCookie, InBody} {
+		var bound *Result
+		var err error
+		switch in {
+		case InBody:
+			bound, err = p.bindBody(v, req, nil)
+		case InQuery, InForm:
+			bound, err = p.bindLocated(v, req, in, in, deferAbsent)
+		default:
+			bound, err = p.bindLocated(v, req, in, InQuery, deferAbsent)
+		}
+		if bound != nil {
+			result.Params = append(result.Params, bound.Params...)
+			result.Warnings = append(result.Warnings, bound.Warnings...)
 		}
+		if err == nil {
+			continue
+		}
+		if !p.opts.aggregateErrors {
+			return result, p.opts.withStatus(err)
+		}
+		if nested, ok := err.(Errors); ok {
+			errs = append(errs, nested...)
+			continue
+		}
+		errs = append(errs, err)
+	}
 
-		if !array {
-			return str[0], true
+	bound := map[string]bool{}
+	for _, prm := range result.Params {
+		bound[prm.Field] = true
+	}
+	for _, prm := range absent {
+		if bound[prm.field.Name] {
+			continue
+		}
+		bound[prm.field.Name] = true
+		err := p.absent(reflect.ValueOf(v).Elem().FieldByIndex(prm.field.Index), prm)
+		if err != nil && !p.opts.aggregateErrors {
+			return result, p.opts.withStatus(err)
 		}
-		return str, true
-	})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return result, p.opts.withStatus(errs)
+	}
+	return result, nil
 }
 
-// ExtractForms extract http form values from sent request and binds to v
-func (p extractor) ExtractForms(v interface{}, req *http.Request) error {
-	err := req.ParseForm()
+// bindLocated binds params of the in location of req into fields located in the in location.
+// Fields without the in option are bound when fallback is in, and absent fields are handled by absent
+func (p extractor) bindLocated(v interface{}, req *http.Request, in, fallback In,
+	absent func(value reflect.Value, prm param) error) (*Result, error) {
+	src, err := p.source(req, in)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return p.extract(v, func(key string, array bool) (interface{}, bool) {
-		str := req.PostForm[key]
-		if len(str) == 0 {
-			return "", false
-		}
+	return p.extractLocated(v, in, fallback, src, absent)
+}
 
-		if !array {
-			return str[0], true
+// source returns the source of params of the in location of req
+func (p extractor) source(req *http.Request, in In) (Source, error) {
+	switch in {
+	case InHeader:
+		return headerSource(req.Header), nil
+	case InQuery:
+		return valuesSource(req.URL.Query()), nil
+	case InForm:
+		err := p.parseForm(req)
+		if err != nil {
+			return nil, parseFormError(err)
 		}
-		return str, true
-	})
+		return valuesSource(req.PostForm), nil
+	case InPath:
+		return pathSource{req: req, value: p.opts.pathValue}, nil
+	case InCookie:
+		return cookieSource(req.Cookies()), nil
+	default:
+		return nil, ErrorUnSupportedParamType{fmt.Errorf(`unsupported param location "%v"`, in)}
+	}
+}
+
+// ExtractJSON decodes the JSON request body into the body field of v
+func (p extractor) ExtractJSON(v interface{}, req *http.Request) error {
+	_, err := p.bindBody(v, req, decodeJSON)
+	return p.opts.withStatus(err)
+}
+
+// ExtractBody decodes the request body into the body field of v. JSON, XML, url encoded and multipart
+// forms and plain text bodies are decoded by default, and decoders of other types are set by WithDecoder
+func (p extractor) ExtractBody(v interface{}, req *http.Request) error {
+	_, err := p.Bind(v, req, InBody)
+	return err
+}
+
+// ExtractEnv extract environment variables and binds to v. Slices are comma separated, same as headers
+func (p extractor) ExtractEnv(v interface{}) error {
+	_, err := p.BindFrom(v, envSource{prefix: p.opts.envPrefix}, InEnv)
+	return err
+}
+
+// ExtractFrom extract parameters from src and binds to v
+func (p extractor) ExtractFrom(v interface{}, src Source) error {
+	in := InQuery
+	if s, ok := src.(locatedSource); ok {
+		in = s.in()
+	}
+	_, err := p.BindFrom(v, src, in)
+	return err
+}
+
+// BindFrom extract parameters of the in location from src and binds to v
+func (p extractor) BindFrom(v interface{}, src Source, in In) (*Result, error) {
+	if _, ok := styles[in]; !ok {
+		return nil, p.opts.withStatus(ErrorUnSupportedParamType{fmt.Errorf(`unsupported param location "%v"`, in)})
+	}
+	result, err := p.extract(v, in, src)
+	return result, p.opts.withStatus(err)
 }
 
-func (p extractor) extract(v interface{}, keyExtractor extractorFunc) error {
+func (p extractor) parseForm(req *http.Request) error {
+	contentType, _, err := mime.ParseMediaType(req.Header.Get(`Content-Type`))
+	if err == nil && contentType == `multipart/form-data` {
+		return req.ParseMultipartForm(p.opts.maxMemory)
+	}
+	return req.ParseForm()
+}
+
+// parseFormError converts errors of http.Request.ParseForm into paramex errors
+func parseFormError(err error) error {
+	switch {
+	case err.Error() == `http: request body too large` || err.Error() == `http: POST too large`,
+		errors.Is(err, multipart.ErrMessageTooLarge):
+		return ErrorBodyTooLarge{err}
+	case strings.HasPrefix(err.Error(), `mime:`):
+		return ErrorUnsupportedMediaType{err}
+	default:
+		return ErrorMalformedRequest{err}
+	}
+}
+
+// param is a struct field bound to a request parameter
+type param struct {
+	field reflect.StructField
+	// path is the Go field path of nested fields, e.g. Filter.Owner.ID
+	path string
+	// key is the matched key, or the first key when no key is matched
+	key  string
+	keys []string
+	in   In
+	tag  paramTag
+}
+
+func (prm param) fieldError(err error) *FieldError {
+	field := prm.field.Name
+	if prm.path != `` {
+		field = prm.path
+	}
+	return &FieldError{Field: field, Name: prm.key, In: prm.in, Err: err}
+}
+
+// extract binds params of the in location from src into v. Fields without the in option are bound
+// from every location
+func (p extractor) extract(v interface{}, in In, src Source) (*Result, error) {
+	return p.extractLocated(v, in, ``, src, p.absent)
+}
+
+// extractLocated binds params of the in location from src into fields of v located in the in location,
+// where fields without the in option are located in the fallback location. Absent fields are handled by absent
+func (p extractor) extractLocated(v interface{}, in, fallback In, src Source,
+	absent func(value reflect.Value, prm param) error) (*Result, error) {
 	t := reflect.TypeOf(v)
-	if t.Kind() != reflect.Ptr || v == nil {
-		return ErrorNotAssignable{
+	if v == nil || t.Kind() != reflect.Ptr {
+		return nil, ErrorNotAssignable{
 			fmt.Errorf(`type of %v is not assignabale, required object reference`, t)}
 	}
 
 	elem := reflect.ValueOf(v).Elem()
 	if elem.Kind() != reflect.Struct {
-		return ErrorUnSupportedType{
+		return nil, ErrorUnSupportedType{
 			fmt.Errorf(`type of %v is not extractable, required struct object`, elem.Type().String())}
 	}
 
+	if p.opts.normalizer != nil && in != InHeader && in != InPath {
+		src = newNormalizedSource(src, p.opts.normalizer)
+	}
+
+	result := &Result{}
+	var errs Errors
+	var declared []string
 	t = elem.Type()
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
 
-		tag, ok := field.Tag.Lookup(`param`)
-		if !ok || tag == `-` {
+		tag, ok := p.opts.fieldTag(field)
+		if !ok || !tag.located(in, fallback) {
 			continue
 		}
+		keys := tag.keys()
+		prm := param{field: field, key: keys[0], keys: keys, in: in, tag: tag}
+		bind := p.bind
+		if tag.prefix() && field.Type.Kind() == reflect.Map {
+			for i, key := range keys {
+				keys[i] = strings.TrimSuffix(key, `*`)
+				declared = append(declared, keys[i]+`*`)
+			}
+			bind = p.bindPrefix
+		} else {
+			declared = append(declared, keys...)
+			if _, scalar := p.opts.converter(field.Type); !scalar {
+				// bracket notation keys are declared by fields bound from them, e.g. filter[owner]
+				for _, key := range keys {
+					declared = append(declared, key+`[]`)
+				}
+			}
+		}
 
-		_, ok = keyExtractor(tag, false)
-		if !ok {
+		bound, err := bind(elem.Field(i), prm, src)
+		switch {
+		case err != nil:
+		case bound == nil:
+			err = absent(elem.Field(i), prm)
+		default:
+			result.Params = append(result.Params, *bound)
+			result.Warnings = append(result.Warnings, warnings(prm, *bound)...)
+			err = p.validate(elem.Field(i), prm)
+		}
+		if err == nil {
 			continue
 		}
+		if !p.opts.aggregateErrors {
+			return result, err
+		}
+		errs = append(errs, err)
+	}
 
-		switch field.Type {
-		case reflect.TypeOf(stringType):
-			valueStr, _ := keyExtractor(tag, false)
-			elem.Field(i).Set(reflect.ValueOf(valueStr.(string)))
+	if p.opts.strict && in.keyed() {
+		err := p.unknownParams(declared, in, src)
+		if err != nil && !p.opts.aggregateErrors {
+			return result, err
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
 
-		case reflect.TypeOf(boolType):
-			valueStr, _ := keyExtractor(tag, false)
-			value, err := strconv.ParseBool(valueStr.(string))
-			if err != nil {
-				return ErrorUnmarshalType{
-					fmt.Errorf(`error unmarshalling [%v] into [bool] due to %v`, valueStr, err)}
-			}
-			elem.Field(i).Set(reflect.ValueOf(value))
-
-		case reflect.TypeOf(int32Type):
-			valueStr, _ := keyExtractor(tag, false)
-			value, err := strconv.Atoi(valueStr.(string))
-			if err != nil {
-				return ErrorUnmarshalType{
-					fmt.Errorf(`error unmarshalling [%v] into [int32] due to %v`, valueStr, err)}
-			}
-			elem.Field(i).Set(reflect.ValueOf(int32(value)))
-
-		case reflect.TypeOf(intType):
-			valueStr, _ := keyExtractor(tag, false)
-			value, err := strconv.Atoi(valueStr.(string))
-			if err != nil {
-				return ErrorUnmarshalType{
-					fmt.Errorf(`error unmarshalling [%v] into [int] due to %v`, valueStr, err)}
-			}
-			elem.Field(i).Set(reflect.ValueOf(value))
-
-		case reflect.TypeOf(int64Type):
-			valueStr, _ := keyExtractor(tag, false)
-			value, err := strconv.ParseInt(valueStr.(string), 10, 64)
-			if err != nil {
-				return ErrorUnmarshalType{
-					fmt.Errorf(`error unmarshalling [%v] into [int64] due to %v`, valueStr, err)}
-			}
-			elem.Field(i).Set(reflect.ValueOf(value))
-
-		case reflect.TypeOf(float32Type):
-			valueStr, _ := keyExtractor(tag, false)
-			value, err := strconv.ParseFloat(valueStr.(string), 32)
-			if err != nil {
-				return ErrorUnmarshalType{
-					fmt.Errorf(`error unmarshalling [%v] into [float32] due to %v`, valueStr, err)}
-			}
-			elem.Field(i).Set(reflect.ValueOf(float32(value)))
-
-		case reflect.TypeOf(float64Type):
-			valueStr, _ := keyExtractor(tag, false)
-			value, err := strconv.ParseFloat(valueStr.(string), 64)
-			if err != nil {
-				return ErrorUnmarshalType{
-					fmt.Errorf(`error unmarshalling [%v] into [float64] due to %v`, valueStr, err)}
-			}
-			elem.Field(i).Set(reflect.ValueOf(value))
-
-		case reflect.TypeOf(uuid.UUID{}):
-			valueStr, _ := keyExtractor(tag, false)
-			value, err := uuid.Parse(valueStr.(string))
-			if err != nil {
-				return ErrorUnmarshalType{
-					fmt.Errorf(`error unmarshalling [%v] into [uuid] due to %v`, valueStr, err)}
-			}
-			elem.Field(i).Set(reflect.ValueOf(value))
+	if len(errs) > 0 {
+		return result, errs
+	}
+	return result, nil
+}
 
-		case reflect.TypeOf([]string{stringType}):
-			valueStr, ok := keyExtractor(tag, true)
-			if !ok {
-				return ErrorUnSupportedParamType{
-					fmt.Errorf(`error unmarshalling []string into "%v", unsupported param type`, tag),
-				}
-			}
-			elem.Field(i).Set(reflect.ValueOf(valueStr.([]string)))
+// bind sets value to the converted values of prm. Absent parameters are skipped
+func (p extractor) bind(value reflect.Value, prm param, src Source) (*BoundParam, error) {
+	style, styled, err := p.opts.style(prm)
+	if err != nil {
+		return nil, err
+	}
+	if styled {
+		return p.bindStyled(value, prm, style, src)
+	}
 
-		default:
-			return ErrorUnSupportedParamType{errors.New(`unsupported param extractor type`)}
+	values, prm, ok, err := lookup(src, prm)
+	if err != nil {
+		return nil, err
+	}
+	// fields having a converter are not bound from bracket notation keys, e.g. name[x]
+	_, scalar := p.opts.converter(prm.field.Type)
+	if prm.in.keyed() && !scalar && (!ok || p.nested(prm.field.Type)) {
+		bound, err := p.bindBrackets(value, prm, src)
+		if err != nil || bound != nil {
+			return bound, err
+		}
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	err = p.set(value, prm, values)
+	if err != nil {
+		return nil, err
+	}
+	return &BoundParam{Field: prm.field.Name, Key: prm.key, In: prm.in, Alias: prm.key != prm.keys[0]}, nil
+}
+
+// warnings returns warnings of a bound parameter, which are raised by the
+// deprecated tag option, e.g. `param:"sort_by,deprecated=use sort"`
+func warnings(prm param, bound BoundParam) []Warning {
+	note, ok := prm.tag.option(`deprecated`)
+	if !ok {
+		return nil
+	}
+
+	message := fmt.Sprintf(`param "%v" is deprecated`, bound.Key)
+	if note != `` {
+		message += `, ` + note
+	}
+	return []Warning{{Field: bound.Field, Key: bound.Key, In: bound.In, Message: message}}
+}
+
+// set sets value to the converted values of prm
+func (p extractor) set(value reflect.Value, prm param, values []string) error {
+	return p.setValue(value, prm.field.Type, prm, values)
+}
+
+// setValue sets value of type t to the converted values of prm
+func (p extractor) setValue(value reflect.Value, t reflect.Type, prm param, values []string) error {
+	if c, ok := p.opts.converter(t); ok {
+		str, err := p.single(prm, values)
+		if err != nil {
+			return err
 		}
+		converted, err := c.convertTo(prm, t, str)
+		if err != nil {
+			return err
+		}
+		value.Set(converted)
+		return nil
+	}
+
+	if t.Kind() == reflect.Map {
+		return ErrorUnSupportedParamType{prm.fieldError(errUnsupportedPrefix)}
+	}
+	if t.Kind() != reflect.Slice {
+		return ErrorUnSupportedParamType{prm.fieldError(
+			errors.New(`unsupported param extractor type`))}
 	}
 
+	c, ok := p.opts.converter(t.Elem())
+	if !ok {
+		return ErrorUnSupportedParamType{prm.fieldError(
+			fmt.Errorf(`error unmarshalling %v into "%v", unsupported param type`, t, prm.key))}
+	}
+	slice := reflect.MakeSlice(t, 0, len(values))
+	for _, str := range values {
+		converted, err := c.convertTo(prm, t.Elem(), str)
+		if err != nil {
+			return err
+		}
+		slice = reflect.Append(slice, converted)
+	}
+	value.Set(slice)
+
 	return nil
 }
+
+// single returns the value of a non slice field according to the multiple values policy of prm
+func (p extractor) single(prm param, values []string) (string, error) {
+	policy := p.opts.multipleValues
+	if name, ok := prm.tag.option(`multi`); ok {
+		var err error
+		policy, err = parseMultipleValuesPolicy(name)
+		if err != nil {
+			return ``, ErrorUnSupportedParamType{prm.fieldError(err)}
+		}
+	}
+
+	switch {
+	case len(values) == 1 || policy == FirstValue:
+		return values[0], nil
+	case policy == LastValue:
+		return values[len(values)-1], nil
+	default:
+		return ``, ErrorMultipleValues{
+			error: prm.fieldError(fmt.Errorf(`multiple values [%v] received for single value param "%v"`,
+				strings.Join(values, `, `), prm.key)),
+			In:     prm.in,
+			Key:    prm.key,
+			Values: values,
+		}
+	}
+}
+
+// unknownParams returns an ErrorUnknownParams listing keys of src which are neither
+// declared by any field nor allowed by WithStrict
+func (p extractor) unknownParams(declared []string, in In, src Source) error {
+	var unknown []string
+	for _, key := range src.Keys() {
+		if !p.declared(key, declared) && !p.allowed(key) {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	sort.Strings(unknown)
+	return ErrorUnknownParams{
+		error: fmt.Errorf(`unknown %s params %v`, in, strings.Join(unknown, `, `)),
+		In:    in,
+		Keys:  unknown,
+	}
+}
+
+// declared reports whether key is declared. Declared keys ending with * are prefixes of map fields
+// and bracket notation keys are declared by fields without a converter, e.g. filter[owner] by filter[]
+func (p extractor) declared(key string, declared []string) bool {
+	if base, _, ok := parseBracketKey(key); ok {
+		key = base + `[]`
+	}
+	for _, d := range declared {
+		if prefix := strings.TrimSuffix(d, `*`); prefix != d && strings.HasPrefix(key, prefix) {
+			return true
+		}
+		if key == d || p.opts.normalizer != nil && p.opts.normalizer(key) == p.opts.normalizer(d) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p extractor) allowed(key string) bool {
+	if p.opts.normalizer != nil {
+		key = p.opts.normalizer(key)
+	}
+	for _, pattern := range p.opts.allowedKeys {
+		if p.opts.normalizer != nil {
+			pattern = p.opts.normalizer(pattern)
+		}
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package paramex
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -8,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -85,6 +87,7 @@ func TestExtractor_Types(t *testing.T) {
 	reqForm.Set(`float32`, `123.456`)
 	reqForm.Set(`float64`, `987.654`)
 	reqForm.Set(`uuid`, testUUID.String())
+	reqForm.Set(`time`, `2024-05-01T10:00:00+05:30`)
 	reqForm[`strArray`] = testStrArray
 
 	req, err := http.NewRequest(`POST`, "https://nipuna.lk", strings.NewReader(reqForm.Encode()))
@@ -127,6 +130,10 @@ func TestExtractor_Types(t *testing.T) {
 	if obj.TypeUUID != testUUID {
 		t.Fatalf(`expected [%v], but received [%v]`, testUUID, obj.TypeUUID)
 	}
+	testTime := time.Date(2024, 5, 1, 10, 0, 0, 0, time.FixedZone(``, 19800))
+	if !obj.TypeTime.Equal(testTime) {
+		t.Fatalf(`expected [%v], but received [%v]`, testTime, obj.TypeTime)
+	}
 	if !reflect.DeepEqual(obj.TypeStringArray, testStrArray) {
 		t.Fatalf(`expected [%v], but received [%v]`, testStrArray, obj.TypeStringArray)
 	}
@@ -357,6 +364,180 @@ func TestExtractor_Errors(t *testing.T) {
 	})
 }
 
+func TestExtractor_ExtractForms_Errors(t *testing.T) {
+	t.Run(`test body too large error`, func(t *testing.T) {
+		req, err := http.NewRequest(`POST`, `https://nipuna.lk`, strings.NewReader(`name=form_name&age=50`))
+		if err != nil {
+			t.Fatal(`error creating request`, err)
+		}
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		req.Body = http.MaxBytesReader(nil, req.Body, 5)
+
+		err = NewParamExtractor().ExtractForms(&formParams{}, req)
+		_, ok := err.(ErrorBodyTooLarge)
+		if !ok {
+			t.Errorf(`expected "ErrorBodyTooLarge", but received %v`, reflect.TypeOf(err))
+		}
+	})
+
+	t.Run(`test unsupported media type error`, func(t *testing.T) {
+		req, err := http.NewRequest(`POST`, `https://nipuna.lk`, strings.NewReader(`name=form_name`))
+		if err != nil {
+			t.Fatal(`error creating request`, err)
+		}
+		req.Header.Add("Content-Type", "application/")
+
+		err = NewParamExtractor().ExtractForms(&formParams{}, req)
+		_, ok := err.(ErrorUnsupportedMediaType)
+		if !ok {
+			t.Errorf(`expected "ErrorUnsupportedMediaType", but received %v`, reflect.TypeOf(err))
+		}
+	})
+
+	t.Run(`test malformed request error`, func(t *testing.T) {
+		req, err := http.NewRequest(`POST`, `https://nipuna.lk`, strings.NewReader(`name=%zz`))
+		if err != nil {
+			t.Fatal(`error creating request`, err)
+		}
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+		err = NewParamExtractor().ExtractForms(&formParams{}, req)
+		_, ok := err.(ErrorMalformedRequest)
+		if !ok {
+			t.Errorf(`expected "ErrorMalformedRequest", but received %v`, reflect.TypeOf(err))
+		}
+	})
+
+	t.Run(`test overridden status code`, func(t *testing.T) {
+		req, err := makeRequest()
+		if err != nil {
+			t.Fatal(`error creating request`, err)
+		}
+
+		extractor := NewParamExtractor(WithStatusCode(ErrorUnmarshalType{}, http.StatusUnprocessableEntity))
+		err = extractor.ExtractForms(&boolError{}, req)
+		unmarshalErr, ok := err.(ErrorUnmarshalType)
+		if !ok {
+			t.Fatalf(`expected "ErrorUnmarshalType", but received %v`, reflect.TypeOf(err))
+		}
+		if unmarshalErr.StatusCode() != http.StatusUnprocessableEntity {
+			t.Errorf(`expected [%d], but received [%d]`, http.StatusUnprocessableEntity, unmarshalErr.StatusCode())
+		}
+	})
+}
+
+func TestExtractor_Bind(t *testing.T) {
+	t.Run(`test alias keys`, func(t *testing.T) {
+		req, err := http.NewRequest(`GET`, `https://nipuna.lk?userId=5&user_name=nipuna&userName=senpathi`, nil)
+		if err != nil {
+			t.Fatal(`error creating request`, err)
+		}
+		req.Header.Set(`Api-Key`, `secret`)
+
+		obj := struct {
+			UserID   int    `param:"user_id|userId"`
+			UserName string `param:"user_name|userName"`
+			Age      int    `param:"age|years"`
+			APIKey   string `param:"X-Api-Key|Api-Key"`
+		}{}
+		extractor := NewParamExtractor()
+		result, err := extractor.Bind(&obj, req, InQuery)
+		if err != nil {
+			t.Fatalf(`error extracting queries due to %v`, err)
+		}
+		if obj.UserID != 5 || obj.UserName != `nipuna` || obj.Age != 0 {
+			t.Errorf(`unexpected extracted values [%+v]`, obj)
+		}
+		expected := []BoundParam{
+			{Field: `UserID`, Key: `userId`, In: InQuery, Alias: true},
+			{Field: `UserName`, Key: `user_name`, In: InQuery},
+		}
+		if !reflect.DeepEqual(result.Params, expected) {
+			t.Errorf(`expected [%+v], but received [%+v]`, expected, result.Params)
+		}
+		if !reflect.DeepEqual(result.Aliases(), expected[:1]) {
+			t.Errorf(`expected [%+v], but received [%+v]`, expected[:1], result.Aliases())
+		}
+
+		result, err = extractor.Bind(&obj, req, InHeader)
+		if err != nil {
+			t.Fatalf(`error extracting headers due to %v`, err)
+		}
+		if obj.APIKey != `secret` || len(result.Aliases()) != 1 || result.Aliases()[0].Key != `Api-Key` {
+			t.Errorf(`unexpected result [%+v] of [%+v]`, result, obj)
+		}
+	})
+
+	t.Run(`test alias key errors`, func(t *testing.T) {
+		req, err := http.NewRequest(`GET`, `https://nipuna.lk?userId=five&limit=1`, nil)
+		if err != nil {
+			t.Fatal(`error creating request`, err)
+		}
+		obj := struct {
+			UserID int `param:"user_id|userId"`
+		}{}
+		_, err = NewParamExtractor(WithStrict()).Bind(&obj, req, InQuery)
+		fieldErr := &FieldError{}
+		if !errors.As(err, &fieldErr) || fieldErr.Name != `userId` {
+			t.Errorf(`expected error of "userId", but received %v`, err)
+		}
+
+		req.URL.RawQuery = `user_id=5&userId=6&limit=1`
+		_, err = NewParamExtractor(WithStrict()).Bind(&obj, req, InQuery)
+		unknownErr, ok := err.(ErrorUnknownParams)
+		if !ok || !reflect.DeepEqual(unknownErr.Keys, []string{`limit`}) {
+			t.Errorf(`expected unknown param "limit", but received %v`, err)
+		}
+	})
+
+	t.Run(`test deprecated params`, func(t *testing.T) {
+		req, err := http.NewRequest(`GET`, `https://nipuna.lk?sort_by=name&order=asc&sortBy=age`, nil)
+		if err != nil {
+			t.Fatal(`error creating request`, err)
+		}
+		obj := struct {
+			Sort   string `param:"sort|sort_by,deprecated=use sort"`
+			Order  string `param:"order,deprecated"`
+			Filter string `param:"filter,deprecated"`
+		}{}
+		result, err := NewParamExtractor().Bind(&obj, req, InQuery)
+		if err != nil {
+			t.Fatalf(`error extracting queries due to %v`, err)
+		}
+		if obj.Sort != `name` || obj.Order != `asc` {
+			t.Errorf(`unexpected extracted values [%+v]`, obj)
+		}
+		expected := []Warning{
+			{Field: `Sort`, Key: `sort_by`, In: InQuery, Message: `param "sort_by" is deprecated, use sort`},
+			{Field: `Order`, Key: `order`, In: InQuery, Message: `param "order" is deprecated`},
+		}
+		if !reflect.DeepEqual(result.Warnings, expected) {
+			t.Errorf(`expected [%+v], but received [%+v]`, expected, result.Warnings)
+		}
+
+		header := http.Header{}
+		SetWarningHeaders(header, result, nil)
+		if header.Get(`Deprecation`) != `true` {
+			t.Errorf(`expected [true], but received [%v]`, header.Get(`Deprecation`))
+		}
+		expectedHeaders := []string{`299 - "param \"sort_by\" is deprecated, use sort"`, `299 - "param \"order\" is deprecated"`}
+		if !reflect.DeepEqual(header.Values(`Warning`), expectedHeaders) {
+			t.Errorf(`expected [%v], but received [%v]`, expectedHeaders, header.Values(`Warning`))
+		}
+	})
+
+	t.Run(`test unsupported location`, func(t *testing.T) {
+		req, err := makeRequest()
+		if err != nil {
+			t.Fatal(`error creating request`, err)
+		}
+		_, err = NewParamExtractor().Bind(&queryParams{}, req, In(`trailer`))
+		if _, ok := err.(ErrorUnSupportedParamType); !ok {
+			t.Errorf(`expected "ErrorUnSupportedParamType", but received %v`, reflect.TypeOf(err))
+		}
+	})
+}
+
 type headerParams struct {
 	Name    string  `param:"name"`
 	Age     int64   `param:"age"`
@@ -387,6 +568,7 @@ type types struct {
 	TypeFloat32     float32   `param:"float32"`
 	TypeFloat64     float64   `param:"float64"`
 	TypeUUID        uuid.UUID `param:"uuid"`
+	TypeTime        time.Time `param:"time"`
 	TypeStringArray []string  `param:"strArray"`
 }
 
@@ -0,0 +1,61 @@
+package paramex
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// bindPrefix sets a map field to values of every key starting with a key of prm, where map keys
+// are the request keys with the prefix stripped. Keys of the first prefix matching any key are used
+func (p extractor) bindPrefix(value reflect.Value, prm param, src Source) (*BoundParam, error) {
+	t := prm.field.Type
+	if t.Key().Kind() != reflect.String {
+		return nil, ErrorUnSupportedParamType{prm.fieldError(
+			fmt.Errorf(`error unmarshalling prefix "%v" into %v, map key should be a string`, prm.key, t))}
+	}
+
+	keys := src.Keys()
+	sort.Strings(keys)
+	for _, prefix := range prm.keys {
+		m := reflect.MakeMap(t)
+		for _, key := range keys {
+			if !hasPrefix(key, prefix, prm.in) || len(key) == len(prefix) {
+				continue
+			}
+
+			values, ok := src.Lookup(key)
+			if !ok || len(values) == 0 {
+				continue
+			}
+			entry := prm
+			entry.key = key
+			elem := reflect.New(t.Elem()).Elem()
+			err := p.setValue(elem, t.Elem(), entry, values)
+			if err != nil {
+				return nil, err
+			}
+			m.SetMapIndex(reflect.ValueOf(key[len(prefix):]).Convert(t.Key()), elem)
+		}
+
+		if m.Len() > 0 {
+			value.Set(m)
+			return &BoundParam{Field: prm.field.Name, Key: prefix, In: prm.in, Alias: prefix != prm.keys[0]}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// hasPrefix matches header prefixes case-insensitively, same as header keys
+func hasPrefix(key, prefix string, in In) bool {
+	if in == InHeader {
+		return len(key) >= len(prefix) && strings.EqualFold(key[:len(prefix)], prefix)
+	}
+	return strings.HasPrefix(key, prefix)
+}
+
+// errUnsupportedPrefix is returned for map fields without a prefix tag
+var errUnsupportedPrefix = errors.New(`map fields require a prefix tag such as "meta.*" or "X-Meta-,prefix"`)
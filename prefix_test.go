@@ -0,0 +1,97 @@
+package paramex
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestExtractor_PrefixMaps(t *testing.T) {
+	t.Run(`test query prefix maps`, func(t *testing.T) {
+		req, err := http.NewRequest(`GET`,
+			`https://nipuna.lk?meta.color=red&meta.size=L&meta.=empty&tag.a=1&tag.a=2&tag.b=3&num.x=1.5&name=nipuna`, nil)
+		if err != nil {
+			t.Fatal(`error creating request`, err)
+		}
+		obj := struct {
+			Meta    map[string]string   `param:"meta.*"`
+			Tags    map[string][]int    `param:"tag.,prefix"`
+			Numbers map[string]float64  `param:"num.*"`
+			Missing map[string]string   `param:"missing.*"`
+			Other   map[string][]string `param:"other.*|o.*"`
+			Name    string              `param:"name"`
+		}{}
+
+		result, err := NewParamExtractor(WithStrict()).Bind(&obj, req, InQuery)
+		if err != nil {
+			t.Fatalf(`error extracting queries due to %v`, err)
+		}
+		if !reflect.DeepEqual(obj.Meta, map[string]string{`color`: `red`, `size`: `L`}) {
+			t.Errorf(`unexpected meta %v`, obj.Meta)
+		}
+		if !reflect.DeepEqual(obj.Tags, map[string][]int{`a`: {1, 2}, `b`: {3}}) {
+			t.Errorf(`unexpected tags %v`, obj.Tags)
+		}
+		if !reflect.DeepEqual(obj.Numbers, map[string]float64{`x`: 1.5}) {
+			t.Errorf(`unexpected numbers %v`, obj.Numbers)
+		}
+		if obj.Missing != nil || obj.Other != nil {
+			t.Errorf(`expected nil maps, but received %v %v`, obj.Missing, obj.Other)
+		}
+		if len(result.Params) != 4 || result.Params[0].Key != `meta.` {
+			t.Errorf(`unexpected params %+v`, result.Params)
+		}
+	})
+
+	t.Run(`test header prefix maps`, func(t *testing.T) {
+		req, err := http.NewRequest(`GET`, `https://nipuna.lk`, nil)
+		if err != nil {
+			t.Fatal(`error creating request`, err)
+		}
+		req.Header.Set(`X-Meta-Color`, `red`)
+		req.Header.Set(`x-meta-size`, `L`)
+		obj := struct {
+			Meta map[string]string `param:"x-meta-*"`
+		}{}
+		err = NewParamExtractor().ExtractHeaders(&obj, req)
+		if err != nil {
+			t.Fatalf(`error extracting headers due to %v`, err)
+		}
+		if !reflect.DeepEqual(obj.Meta, map[string]string{`Color`: `red`, `Size`: `L`}) {
+			t.Errorf(`unexpected meta %v`, obj.Meta)
+		}
+	})
+
+	t.Run(`test prefix map errors`, func(t *testing.T) {
+		req, err := http.NewRequest(`GET`, `https://nipuna.lk?num.x=one&meta.a=1`, nil)
+		if err != nil {
+			t.Fatal(`error creating request`, err)
+		}
+
+		numbers := struct {
+			Numbers map[string]int `param:"num.*"`
+		}{}
+		err = NewParamExtractor().ExtractQueries(&numbers, req)
+		fieldErr := &FieldError{}
+		if _, ok := err.(ErrorUnmarshalType); !ok || !errors.As(err, &fieldErr) || fieldErr.Name != `num.x` {
+			t.Errorf(`expected "ErrorUnmarshalType" of "num.x", but received %v`, err)
+		}
+
+		intKeys := struct {
+			Meta map[int]string `param:"meta.*"`
+		}{}
+		err = NewParamExtractor().ExtractQueries(&intKeys, req)
+		if _, ok := err.(ErrorUnSupportedParamType); !ok {
+			t.Errorf(`expected "ErrorUnSupportedParamType", but received %v`, reflect.TypeOf(err))
+		}
+
+		noPrefix := struct {
+			Meta map[string]string `param:"meta.a"`
+		}{}
+		err = NewParamExtractor().ExtractQueries(&noPrefix, req)
+		if _, ok := err.(ErrorUnSupportedParamType); !ok || !errors.As(err, &fieldErr) || fieldErr.Err != errUnsupportedPrefix {
+			t.Errorf(`expected "ErrorUnSupportedParamType", but received %v`, err)
+		}
+	})
+}
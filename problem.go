@@ -0,0 +1,116 @@
+package paramex
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details documents
+const ProblemContentType = `application/problem+json`
+
+// Problem is an RFC 7807 problem details document
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// InvalidParam describes a parameter which failed binding
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Source In     `json:"source"`
+	Reason string `json:"reason"`
+}
+
+// NewProblem returns the problem details document of err. err can be any paramex error,
+// an Errors collection or a *FieldError. Messages of server errors are not disclosed
+func NewProblem(err error) *Problem {
+	status := statusCode(err)
+	problem := &Problem{
+		Type:   `about:blank`,
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+	if status >= http.StatusInternalServerError {
+		return problem
+	}
+
+	problem.Detail = err.Error()
+	problem.InvalidParams = invalidParams(err)
+	return problem
+}
+
+// invalidParams returns the invalid params of err, and of every error of err wrapping several errors such as Errors
+func invalidParams(err error) []InvalidParam {
+	if multi, ok := err.(interface{ Unwrap() []error }); ok {
+		var params []InvalidParam
+		for _, err := range multi.Unwrap() {
+			params = append(params, invalidParams(err)...)
+		}
+		return params
+	}
+
+	unknownErr := ErrorUnknownParams{}
+	if errors.As(err, &unknownErr) {
+		params := make([]InvalidParam, 0, len(unknownErr.Keys))
+		for _, key := range unknownErr.Keys {
+			params = append(params, InvalidParam{
+				Name:   key,
+				Source: unknownErr.In,
+				Reason: `unknown parameter`,
+			})
+		}
+		return params
+	}
+
+	fieldErr := &FieldError{}
+	if !errors.As(err, &fieldErr) {
+		return nil
+	}
+	return []InvalidParam{{
+		Name:   fieldErr.Name,
+		Source: fieldErr.In,
+		Reason: fieldErr.Err.Error(),
+	}}
+}
+
+// Write writes the problem to w with the application/problem+json content type
+func (p *Problem) Write(w http.ResponseWriter) error {
+	if p.Title == `` {
+		p.Title = http.StatusText(p.Status)
+	}
+
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set(`Content-Type`, ProblemContentType)
+	w.WriteHeader(p.Status)
+	_, err = w.Write(body)
+	return err
+}
+
+// WriteProblem writes err to w as an RFC 7807 problem details document
+func WriteProblem(w http.ResponseWriter, err error) error {
+	return NewProblem(err).Write(w)
+}
+
+// ProblemErrorWriter is an ErrorWriterFunc for NewHandler which writes
+// errors as RFC 7807 problem details documents
+func ProblemErrorWriter(w http.ResponseWriter, _ *http.Request, err error, status int) {
+	problem := NewProblem(err)
+	if problem.Status != status {
+		problem.Status = status
+		problem.Title = http.StatusText(status)
+	}
+	if status >= http.StatusInternalServerError {
+		problem.Detail = ``
+		problem.InvalidParams = nil
+	}
+
+	_ = problem.Write(w)
+}
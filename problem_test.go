@@ -0,0 +1,95 @@
+package paramex
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestWriteProblem(t *testing.T) {
+	t.Run(`test field errors`, func(t *testing.T) {
+		req := httptest.NewRequest(`GET`, `https://nipuna.lk?name=nipuna&age=old`, nil)
+		err := NewParamExtractor().ExtractQueries(&handlerParams{}, req)
+		if err == nil {
+			t.Fatal(`expected error, but received nil`)
+		}
+
+		headerErr := NewParamExtractor().ExtractHeaders(&boolError{}, func() *http.Request {
+			req := httptest.NewRequest(`GET`, `https://nipuna.lk`, nil)
+			req.Header.Set(`name`, `yes`)
+			return req
+		}())
+
+		rec := httptest.NewRecorder()
+		err = WriteProblem(rec, Errors{err, headerErr})
+		if err != nil {
+			t.Fatalf(`error writing problem due to %v`, err)
+		}
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf(`expected [%d], but received [%d]`, http.StatusBadRequest, rec.Code)
+		}
+		if rec.Header().Get(`Content-Type`) != ProblemContentType {
+			t.Errorf(`expected [%s], but received [%v]`, ProblemContentType, rec.Header().Get(`Content-Type`))
+		}
+
+		problem := Problem{}
+		err = json.NewDecoder(rec.Body).Decode(&problem)
+		if err != nil {
+			t.Fatalf(`error decoding problem due to %v`, err)
+		}
+		if problem.Type != `about:blank` || problem.Title != `Bad Request` || problem.Status != http.StatusBadRequest {
+			t.Errorf(`unexpected problem %+v`, problem)
+		}
+		expected := []InvalidParam{
+			{
+				Name:   `age`,
+				Source: InQuery,
+				Reason: `error unmarshalling [old] into [int] due to strconv.Atoi: parsing "old": invalid syntax`,
+			},
+			{
+				Name:   `name`,
+				Source: InHeader,
+				Reason: `error unmarshalling [yes] into [bool] due to strconv.ParseBool: parsing "yes": invalid syntax`,
+			},
+		}
+		if !reflect.DeepEqual(problem.InvalidParams, expected) {
+			t.Errorf(`expected [%+v], but received [%+v]`, expected, problem.InvalidParams)
+		}
+	})
+
+	t.Run(`test server error`, func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		err := WriteProblem(rec, ErrorNotAssignable{errors.New(`secret`)})
+		if err != nil {
+			t.Fatalf(`error writing problem due to %v`, err)
+		}
+
+		problem := Problem{}
+		_ = json.NewDecoder(rec.Body).Decode(&problem)
+		if problem.Status != http.StatusInternalServerError || problem.Detail != `` || problem.InvalidParams != nil {
+			t.Errorf(`unexpected problem %+v`, problem)
+		}
+	})
+
+	t.Run(`test handler error writer`, func(t *testing.T) {
+		req := httptest.NewRequest(`GET`, `https://nipuna.lk?age=old`, nil)
+		rec := httptest.NewRecorder()
+		NewHandler(greet,
+			WithErrorWriter(ProblemErrorWriter),
+			WithStatusMapper(func(err error) int { return http.StatusUnprocessableEntity }),
+		).ServeHTTP(rec, req)
+
+		problem := Problem{}
+		_ = json.NewDecoder(rec.Body).Decode(&problem)
+		if problem.Status != http.StatusUnprocessableEntity || problem.Title != `Unprocessable Entity` {
+			t.Errorf(`unexpected problem %+v`, problem)
+		}
+		if len(problem.InvalidParams) != 1 || problem.InvalidParams[0].Name != `age` {
+			t.Errorf(`unexpected invalid params %+v`, problem.InvalidParams)
+		}
+	})
+}
@@ -0,0 +1,168 @@
+package paramex
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// NewRequest returns a request populated by fields of params, which is the inverse of extracting
+// params from the request. Fields are encoded into the location set by the in tag option, e.g.
+// `param:"id,in=path"`, and fields without the option are encoded into the url query.
+//
+// Path params fill the {name} segments of urlTemplate, e.g. https://nipuna.lk/users/{id},
+// form values are sent as an application/x-www-form-urlencoded body
+func NewRequest(ctx context.Context, method, urlTemplate string, params interface{}, opts ...Option) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, urlTemplate, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = newRequestEncoder(opts).populate(req, params)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// newRequestEncoder returns an encoder encoding fields without the in tag option into the url query
+func newRequestEncoder(opts []Option) encoder {
+	return encoder{opts: newOptions(opts), fallback: InQuery}
+}
+
+// populate sets the url, headers, cookies and body of req to the encoded fields of params
+func (e encoder) populate(req *http.Request, params interface{}) error {
+	path, err := e.encode(params, InPath)
+	if err != nil {
+		return err
+	}
+	err = fillPath(req.URL, path)
+	if err != nil {
+		return e.opts.withStatus(err)
+	}
+
+	queries, err := e.encodeValues(params, InQuery)
+	if err != nil {
+		return err
+	}
+	if len(queries) > 0 {
+		query := req.URL.Query()
+		for key, values := range queries {
+			query[key] = append(query[key], values...)
+		}
+		req.URL.RawQuery = query.Encode()
+	}
+
+	header, err := e.EncodeHeaders(params)
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		req.Header[key] = append(req.Header[key], values...)
+	}
+
+	cookies, err := e.EncodeCookies(params)
+	if err != nil {
+		return err
+	}
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+
+	forms, err := e.EncodeForms(params)
+	if err != nil {
+		return err
+	}
+	if len(forms) > 0 {
+		body := forms.Encode()
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(body)), nil
+		}
+		req.Body, _ = req.GetBody()
+		req.ContentLength = int64(len(body))
+		req.Header.Set(`Content-Type`, `application/x-www-form-urlencoded`)
+	}
+	return nil
+}
+
+// fillPath replaces the {name} segments of the url path by the escaped values of path params
+func fillPath(u *url.URL, params []encodedParam) error {
+	values := map[string]string{}
+	for _, prm := range params {
+		values[prm.key] = prm.value
+	}
+
+	var path, rawPath strings.Builder
+	rest := u.Path
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		name := rest[start+1 : end]
+		value, ok := values[name]
+		if !ok {
+			return ErrorMarshalType{fmt.Errorf(`path param "%v" of url %v is not set`, name, u.Path)}
+		}
+		path.WriteString(rest[:start] + value)
+		rawPath.WriteString((&url.URL{Path: rest[:start]}).EscapedPath() + url.PathEscape(value))
+		rest = rest[end+1:]
+	}
+	path.WriteString(rest)
+	rawPath.WriteString((&url.URL{Path: rest}).EscapedPath())
+
+	u.Path, u.RawPath = path.String(), rawPath.String()
+	return nil
+}
+
+type paramsKey struct{}
+
+// WithParams returns a copy of ctx carrying params, which populate requests sent by a
+// transport returned by NewTransport, e.g. client.Do(req.WithContext(WithParams(ctx, params)))
+func WithParams(ctx context.Context, params interface{}) context.Context {
+	return context.WithValue(ctx, paramsKey{}, params)
+}
+
+type transport struct {
+	base http.RoundTripper
+	enc  encoder
+}
+
+// NewTransport returns an http.RoundTripper populating requests by the params carried
+// by their context, same as NewRequest, before sending them by base. Requests without
+// params are sent unchanged. http.DefaultTransport is used when base is nil
+func NewTransport(base http.RoundTripper, opts ...Option) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return transport{base: base, enc: newRequestEncoder(opts)}
+}
+
+// RoundTrip populates a clone of req, since a RoundTripper should not modify the request
+func (t transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	params := req.Context().Value(paramsKey{})
+	if params == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	populated := req.Clone(req.Context())
+	err := t.enc.populate(populated, params)
+	if err != nil {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, err
+	}
+	return t.base.RoundTrip(populated)
+}
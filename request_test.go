@@ -0,0 +1,110 @@
+package paramex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type requestParams struct {
+	UserID  int      `param:"id,in=path"`
+	Colors  []string `param:"colors,in=path,style=label"`
+	Sort    string   `param:"sort"`
+	Tags    []string `param:"tags,in=query"`
+	TraceID string   `param:"X-Trace-Id,in=header"`
+	Session string   `param:"session,in=cookie"`
+	Note    string   `param:"note,in=form,omitempty"`
+}
+
+// requestServer returns a server extracting requestParams from every location
+func requestServer(t *testing.T, received *requestParams) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(`/users/{id}/colors/{colors}`, func(w http.ResponseWriter, req *http.Request) {
+		extractor := NewParamExtractor()
+		for _, in := range []In{InPath, InQuery, InHeader, InCookie, InForm} {
+			_, err := extractor.Bind(received, req, in)
+			if err != nil {
+				t.Errorf(`error binding %v params due to %v`, in, err)
+			}
+		}
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestNewRequest(t *testing.T) {
+	params := requestParams{
+		UserID:  5,
+		Colors:  []string{`red`, `dark blue`},
+		Sort:    `name`,
+		Tags:    []string{`a`, `b`},
+		TraceID: `trace`,
+		Session: `abc`,
+		Note:    `a&b`,
+	}
+
+	t.Run(`test populated request`, func(t *testing.T) {
+		received := requestParams{}
+		server := requestServer(t, &received)
+		defer server.Close()
+
+		req, err := NewRequest(context.Background(), http.MethodPost,
+			server.URL+`/users/{id}/colors/{colors}?page=2`, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if path := req.URL.EscapedPath(); path != `/users/5/colors/.red.dark%20blue` {
+			t.Errorf(`unexpected path %v`, path)
+		}
+		if query := req.URL.RawQuery; query != `page=2&sort=name&tags=a&tags=b` {
+			t.Errorf(`unexpected query %v`, query)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		if !reflect.DeepEqual(params, received) {
+			t.Errorf(`expected %+v, but received %+v`, params, received)
+		}
+	})
+
+	t.Run(`test missing path param`, func(t *testing.T) {
+		_, err := NewRequest(context.Background(), http.MethodGet, `https://nipuna.lk/users/{user_id}`, params)
+		if _, ok := err.(ErrorMarshalType); !ok {
+			t.Errorf(`expected "ErrorMarshalType", but received %v`, err)
+		}
+	})
+
+	t.Run(`test transport`, func(t *testing.T) {
+		received := requestParams{}
+		server := requestServer(t, &received)
+		defer server.Close()
+
+		client := &http.Client{Transport: NewTransport(nil)}
+		req, _ := http.NewRequestWithContext(WithParams(context.Background(), params),
+			http.MethodPost, server.URL+`/users/{id}/colors/{colors}`, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		if !reflect.DeepEqual(params, received) {
+			t.Errorf(`expected %+v, but received %+v`, params, received)
+		}
+		if req.URL.Path != `/users/{id}/colors/{colors}` || req.Header.Get(`X-Trace-Id`) != `` {
+			t.Errorf(`transport modified the request %v`, req.URL)
+		}
+
+		req, _ = http.NewRequestWithContext(WithParams(context.Background(), 5),
+			http.MethodGet, server.URL+`/users/{id}/colors/{colors}`, nil)
+		_, err = client.Do(req)
+		var unsupported ErrorUnSupportedType
+		if !errors.As(err, &unsupported) {
+			t.Errorf(`expected "ErrorUnSupportedType", but received %v`, err)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package paramex
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryAfter is the time of a Retry-After header, which is sent as delay seconds or an HTTP date,
+// e.g. `param:"Retry-After"`. Delay seconds are relative to the time of binding
+type RetryAfter time.Time
+
+// Time returns the time to retry after
+func (r RetryAfter) Time() time.Time {
+	return time.Time(r)
+}
+
+// Delay returns the duration until the time to retry after, which is zero when the time is past
+func (r RetryAfter) Delay() time.Duration {
+	delay := time.Until(time.Time(r))
+	if delay < 0 {
+		return 0
+	}
+	return delay
+}
+
+// parseRetryAfter parses delay seconds relative to now, or an HTTP date
+func parseRetryAfter(value string, now time.Time) (RetryAfter, error) {
+	if seconds, err := strconv.ParseUint(value, 10, 32); err == nil {
+		return RetryAfter(now.Add(time.Duration(seconds) * time.Second)), nil
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return RetryAfter{}, errors.New(`required delay seconds or an HTTP date`)
+	}
+	return RetryAfter(date), nil
+}
+
+// ExtractResponseHeaders extract http headers from received response and binds to v
+func (p extractor) ExtractResponseHeaders(v interface{}, res *http.Response) error {
+	_, err := p.BindFrom(v, headerSource(res.Header), InHeader)
+	return err
+}
+
+// ExtractResponseCookies extract cookies set by received response and binds to v
+func (p extractor) ExtractResponseCookies(v interface{}, res *http.Response) error {
+	_, err := p.BindFrom(v, cookieSource(res.Cookies()), InCookie)
+	return err
+}
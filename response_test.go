@@ -0,0 +1,83 @@
+package paramex
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type rateLimit struct {
+	Remaining  int        `param:"X-RateLimit-Remaining,required,in=header"`
+	Link       []string   `param:"Link"`
+	RetryAfter RetryAfter `param:"Retry-After"`
+	Session    string     `param:"session,in=cookie"`
+}
+
+func TestExtractor_ExtractResponse(t *testing.T) {
+	extractor := NewParamExtractor()
+	rec := httptest.NewRecorder()
+	rec.Header().Set(`X-RateLimit-Remaining`, `42`)
+	rec.Header().Set(`Link`, `<https://nipuna.lk?page=2>; rel="next", <https://nipuna.lk?page=5>; rel="last"`)
+	rec.Header().Set(`Retry-After`, `120`)
+	http.SetCookie(rec, &http.Cookie{Name: `session`, Value: `abc`})
+	res := rec.Result()
+
+	limit := rateLimit{}
+	err := extractor.ExtractResponseHeaders(&limit, res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if limit.Remaining != 42 || len(limit.Link) != 2 || limit.Link[0] != `<https://nipuna.lk?page=2>; rel="next"` {
+		t.Errorf(`unexpected headers %v`, limit)
+	}
+	if delay := limit.RetryAfter.Delay(); delay <= 110*time.Second || delay > 120*time.Second {
+		t.Errorf(`unexpected retry delay %v`, delay)
+	}
+
+	err = extractor.ExtractResponseCookies(&limit, res)
+	if err != nil || limit.Session != `abc` {
+		t.Errorf(`unexpected cookie %v due to %v`, limit.Session, err)
+	}
+
+	res.Header.Del(`X-RateLimit-Remaining`)
+	err = extractor.ExtractResponseHeaders(&rateLimit{}, res)
+	if _, ok := err.(ErrorRequiredParam); !ok {
+		t.Errorf(`expected "ErrorRequiredParam", but received %v`, err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2015, time.October, 21, 7, 28, 0, 0, time.UTC)
+	tests := map[string]time.Time{
+		`0`:                                 now,
+		`120`:                               now.Add(2 * time.Minute),
+		`Wed, 21 Oct 2015 07:30:00 GMT`:     now.Add(2 * time.Minute),
+		`Wednesday, 21-Oct-15 07:30:00 GMT`: now.Add(2 * time.Minute),
+	}
+	for value, expected := range tests {
+		retry, err := parseRetryAfter(value, now)
+		if err != nil || !retry.Time().Equal(expected) {
+			t.Errorf(`%v: expected %v, but received %v due to %v`, value, expected, retry.Time(), err)
+		}
+	}
+
+	for _, value := range []string{`-1`, `1.5`, `tomorrow`} {
+		if _, err := parseRetryAfter(value, now); err == nil {
+			t.Errorf(`%v: expected an error`, value)
+		}
+	}
+
+	header := http.Header{}
+	header.Set(`X-RateLimit-Remaining`, `1`)
+	header.Set(`Retry-After`, `tomorrow`)
+	err := NewParamExtractor().ExtractResponseHeaders(&rateLimit{}, &http.Response{Header: header})
+	if _, ok := err.(ErrorUnmarshalType); !ok {
+		t.Errorf(`expected "ErrorUnmarshalType", but received %v`, err)
+	}
+
+	values, err := NewParamEncoder().EncodeHeaders(rateLimit{Remaining: 1, RetryAfter: RetryAfter(now.Add(2 * time.Minute))})
+	if err != nil || values.Get(`Retry-After`) != `Wed, 21 Oct 2015 07:30:00 GMT` {
+		t.Errorf(`unexpected header %v due to %v`, values.Get(`Retry-After`), err)
+	}
+}
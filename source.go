@@ -0,0 +1,230 @@
+package paramex
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Source looks up parameter values, e.g. values of a request location, gRPC metadata or message queue headers.
+// Sources are bound by ParamExtractor.ExtractFrom
+type Source interface {
+	// Lookup returns values of key and reports whether key is present. Keys without values,
+	// i.e. an empty slice, are absent the same as keys not present
+	Lookup(key string) ([]string, bool)
+	// Keys returns every key present in the source
+	Keys() []string
+}
+
+// locatedSource is a Source of values of the location returned by in, which is bound by the rules of the location
+type locatedSource interface {
+	Source
+	in() In
+}
+
+// ValuesSource returns a Source of values, which is bound the same as url queries
+func ValuesSource(values url.Values) Source {
+	return valuesSource(values)
+}
+
+// URLSource returns a Source of the url query of u
+func URLSource(u *url.URL) Source {
+	return valuesSource(u.Query())
+}
+
+// HeaderSource returns a Source of header, which is bound the same as request headers
+func HeaderSource(header http.Header) Source {
+	return headerSource(header)
+}
+
+// MapSource returns a Source of single values of m, which is bound the same as url queries
+func MapSource(m map[string]string) Source {
+	return mapSource(m)
+}
+
+type valuesSource url.Values
+
+func (s valuesSource) Lookup(key string) ([]string, bool) {
+	values := s[key]
+	return values, len(values) > 0
+}
+
+func (s valuesSource) Keys() []string {
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// headerSource treats empty headers as absent, same as http.Header.Get
+type headerSource http.Header
+
+func (s headerSource) Lookup(key string) ([]string, bool) {
+	values := http.Header(s).Values(key)
+	if len(values) == 0 || values[0] == `` {
+		return nil, false
+	}
+	return values, true
+}
+
+func (s headerSource) Keys() []string {
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (s headerSource) in() In {
+	return InHeader
+}
+
+type mapSource map[string]string
+
+func (s mapSource) Lookup(key string) ([]string, bool) {
+	value, ok := s[key]
+	if !ok {
+		return nil, false
+	}
+	return []string{value}, true
+}
+
+func (s mapSource) Keys() []string {
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// normalizedSource matches keys having the same normalized form
+type normalizedSource struct {
+	Source
+	normalize KeyNormalizer
+	index     map[string][]string
+}
+
+func newNormalizedSource(src Source, normalize KeyNormalizer) normalizedSource {
+	index := map[string][]string{}
+	for _, key := range src.Keys() {
+		normalized := normalize(key)
+		index[normalized] = append(index[normalized], key)
+	}
+	for _, keys := range index {
+		sort.Strings(keys)
+	}
+	return normalizedSource{Source: src, normalize: normalize, index: index}
+}
+
+// match returns values of the only key matching prm and fails with
+// ErrorAmbiguousKey when several keys match
+func (s normalizedSource) match(prm param) ([]string, bool, error) {
+	keys := s.index[s.normalize(prm.key)]
+	switch len(keys) {
+	case 0:
+		return nil, false, nil
+	case 1:
+		values, ok := s.Source.Lookup(keys[0])
+		return values, ok && len(values) > 0, nil
+	default:
+		return nil, false, ErrorAmbiguousKey{
+			error: prm.fieldError(fmt.Errorf(`ambiguous keys [%v] received for param "%v"`,
+				strings.Join(keys, `, `), prm.key)),
+			In:   prm.in,
+			Key:  prm.key,
+			Keys: keys,
+		}
+	}
+}
+
+// lookup returns values of the first key of prm present in src and prm with the matched key.
+// Keys without values are absent
+func lookup(src Source, prm param) ([]string, param, bool, error) {
+	for _, key := range prm.keys {
+		prm.key = key
+		if s, ok := src.(normalizedSource); ok {
+			values, ok, err := s.match(prm)
+			if err != nil || ok {
+				return values, prm, ok, err
+			}
+			continue
+		}
+		if values, ok := src.Lookup(key); ok && len(values) > 0 {
+			return values, prm, true, nil
+		}
+	}
+	prm.key = prm.keys[0]
+	return nil, prm, false, nil
+}
+
+// pathSource looks up path values of a request. Path keys can not be enumerated,
+// therefore path params are neither matched by normalizers nor checked by strict mode
+type pathSource struct {
+	req   *http.Request
+	value PathValueFunc
+}
+
+func (s pathSource) Lookup(key string) ([]string, bool) {
+	value := s.value(s.req, key)
+	if value == `` {
+		return nil, false
+	}
+	return []string{value}, true
+}
+
+func (s pathSource) Keys() []string {
+	return nil
+}
+
+// cookieSource looks up values of cookies, a cookie sent multiple times has multiple values
+type cookieSource []*http.Cookie
+
+func (s cookieSource) Lookup(key string) ([]string, bool) {
+	var values []string
+	for _, cookie := range s {
+		if cookie.Name == key {
+			values = append(values, cookie.Value)
+		}
+	}
+	return values, len(values) > 0
+}
+
+func (s cookieSource) Keys() []string {
+	keys := make([]string, 0, len(s))
+	seen := map[string]bool{}
+	for _, cookie := range s {
+		if !seen[cookie.Name] {
+			seen[cookie.Name] = true
+			keys = append(keys, cookie.Name)
+		}
+	}
+	return keys
+}
+
+// envSource looks up environment variables having the prefix, treating empty variables as absent
+type envSource struct {
+	prefix string
+}
+
+func (s envSource) Lookup(key string) ([]string, bool) {
+	value, ok := os.LookupEnv(s.prefix + key)
+	if !ok || value == `` {
+		return nil, false
+	}
+	return []string{value}, true
+}
+
+func (s envSource) Keys() []string {
+	var keys []string
+	for _, env := range os.Environ() {
+		key, _, _ := strings.Cut(env, `=`)
+		if key != `` && strings.HasPrefix(key, s.prefix) {
+			keys = append(keys, strings.TrimPrefix(key, s.prefix))
+		}
+	}
+	return keys
+}
@@ -0,0 +1,113 @@
+package paramex
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type sourceParams struct {
+	ID     int64         `param:"id"`
+	Tags   []string      `param:"tags"`
+	Filter openAPIFilter `param:"filter"`
+}
+
+// metadata is a Source of lower case keys, same as gRPC metadata
+type metadata map[string][]string
+
+func (m metadata) Lookup(key string) ([]string, bool) {
+	values, ok := m[strings.ToLower(key)]
+	return values, ok
+}
+
+func (m metadata) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestExtractor_ExtractFrom(t *testing.T) {
+	extractor := NewParamExtractor()
+	u, _ := url.Parse(`https://nipuna.lk?id=7&tags=a&tags=b&filter[owner]=3`)
+	expected := sourceParams{ID: 7, Tags: []string{`a`, `b`}, Filter: openAPIFilter{Owner: 3}}
+
+	sources := map[string]Source{
+		`values`: ValuesSource(u.Query()),
+		`url`:    URLSource(u),
+		`custom`: metadata{`id`: {`7`}, `tags`: {`a`, `b`}, `filter[owner]`: {`3`}},
+	}
+	for name, src := range sources {
+		obj := sourceParams{}
+		err := extractor.ExtractFrom(&obj, src)
+		if err != nil {
+			t.Errorf(`%v: unexpected error %v`, name, err)
+		}
+		if !reflect.DeepEqual(obj, expected) {
+			t.Errorf(`%v: expected %v, but received %v`, name, expected, obj)
+		}
+	}
+
+	t.Run(`test map source`, func(t *testing.T) {
+		obj := sourceParams{}
+		err := extractor.ExtractFrom(&obj, MapSource(map[string]string{`id`: `7`, `tags`: `a`}))
+		if err != nil || obj.ID != 7 || !reflect.DeepEqual(obj.Tags, []string{`a`}) {
+			t.Errorf(`unexpected params %v due to %v`, obj, err)
+		}
+
+		err = extractor.ExtractFrom(&obj, MapSource(map[string]string{`id`: `seven`}))
+		if _, ok := err.(ErrorUnmarshalType); !ok {
+			t.Errorf(`expected "ErrorUnmarshalType", but received %v`, err)
+		}
+	})
+
+	t.Run(`test header source`, func(t *testing.T) {
+		obj := struct {
+			Tenant string   `param:"X-Tenant"`
+			Tags   []string `param:"X-Tags"`
+		}{}
+		header := http.Header{}
+		header.Set(`x-tenant`, `nipuna`)
+		header.Set(`X-Tags`, `a, b`)
+		err := extractor.ExtractFrom(&obj, HeaderSource(header))
+		if err != nil || obj.Tenant != `nipuna` || !reflect.DeepEqual(obj.Tags, []string{`a`, `b`}) {
+			t.Errorf(`unexpected params %v due to %v`, obj, err)
+		}
+	})
+
+	t.Run(`test keys without values`, func(t *testing.T) {
+		obj := struct {
+			ID     int64             `param:"id,default=1"`
+			Tags   []string          `param:"tags"`
+			Labels map[string]string `param:"label.*"`
+		}{}
+		src := metadata{`id`: {}, `tags`: {}, `label.a`: {}, `label.b`: {`b`}}
+		err := extractor.ExtractFrom(&obj, src)
+		if err != nil || obj.ID != 1 || obj.Tags != nil || !reflect.DeepEqual(obj.Labels, map[string]string{`b`: `b`}) {
+			t.Errorf(`unexpected params %v due to %v`, obj, err)
+		}
+
+		err = NewParamExtractor(WithKeyNormalizer(strings.ToLower)).ExtractFrom(&obj, src)
+		if err != nil || obj.ID != 1 {
+			t.Errorf(`unexpected params %v due to %v`, obj, err)
+		}
+	})
+
+	t.Run(`test bind from`, func(t *testing.T) {
+		obj := struct {
+			Session string `param:"session"`
+		}{}
+		result, err := extractor.BindFrom(&obj, MapSource(map[string]string{`session`: `abc`}), InCookie)
+		if err != nil || obj.Session != `abc` || len(result.Params) != 1 || result.Params[0].In != InCookie {
+			t.Errorf(`unexpected params %v due to %v`, obj, err)
+		}
+
+		_, err = extractor.BindFrom(&obj, MapSource(nil), `metadata`)
+		if _, ok := err.(ErrorUnSupportedParamType); !ok {
+			t.Errorf(`expected "ErrorUnSupportedParamType", but received %v`, err)
+		}
+	})
+}
@@ -0,0 +1,354 @@
+package paramex
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// OpenAPI 3 parameter serialization styles, set using the style tag option,
+// e.g. `param:"color,style=pipeDelimited"` or `param:"color,style=matrix,explode"`
+const (
+	styleForm           = `form`
+	styleSpaceDelimited = `spaceDelimited`
+	stylePipeDelimited  = `pipeDelimited`
+	styleDeepObject     = `deepObject`
+	styleSimple         = `simple`
+	styleLabel          = `label`
+	styleMatrix         = `matrix`
+)
+
+// styles are the styles allowed in every location, the first style is the default style
+var styles = map[In][]string{
+	InQuery:  {styleForm, styleSpaceDelimited, stylePipeDelimited, styleDeepObject},
+	InForm:   {styleForm, styleSpaceDelimited, stylePipeDelimited, styleDeepObject},
+	InPath:   {styleSimple, styleLabel, styleMatrix},
+	InHeader: {styleSimple},
+	InCookie: {styleForm},
+	InEnv:    {styleSimple},
+	InFlag:   {styleForm},
+	InBody:   {styleForm},
+}
+
+// valueKind is the kind of a value in OpenAPI serialization
+type valueKind int
+
+const (
+	primitiveKind valueKind = iota
+	arrayKind
+	objectKind
+)
+
+type paramStyle struct {
+	name    string
+	explode bool
+}
+
+// style returns the serialization style of prm and reports whether prm is decoded by the style.
+// Query and form fields without a style option keep decoding repeated keys and bracket notation keys.
+// Objects are decoded only by an explicit style option, since map fields otherwise require a prefix tag
+func (o options) style(prm param) (paramStyle, bool, error) {
+	name, explicit := prm.tag.option(`style`)
+	if !explicit {
+		kind, ok := o.kind(prm.field.Type)
+		if prm.in.keyed() || !ok || kind == objectKind {
+			return paramStyle{}, false, nil
+		}
+		name = styles[prm.in][0]
+	}
+
+	allowed := false
+	for _, style := range styles[prm.in] {
+		allowed = allowed || style == name
+	}
+	if !allowed {
+		return paramStyle{}, false, ErrorUnSupportedParamType{prm.fieldError(
+			fmt.Errorf(`style "%v" is not supported for %v params`, name, prm.in))}
+	}
+
+	s := paramStyle{name: name, explode: name == styleForm || name == styleDeepObject}
+	if explode, ok := prm.tag.option(`explode`); ok {
+		switch explode {
+		case ``, `true`:
+			s.explode = true
+		case `false`:
+			s.explode = false
+		default:
+			return paramStyle{}, false, ErrorUnSupportedParamType{prm.fieldError(
+				fmt.Errorf(`invalid explode "%v", required true or false`, explode))}
+		}
+	}
+	return s, true, nil
+}
+
+// kind returns the serialization kind of values of type t and reports whether t is serializable.
+// Arrays and objects have values having a converter
+func (o options) kind(t reflect.Type) (valueKind, bool) {
+	if _, ok := o.converter(t); ok {
+		return primitiveKind, true
+	}
+	switch t.Kind() {
+	case reflect.Slice:
+		_, ok := o.converter(t.Elem())
+		return arrayKind, ok
+	case reflect.Map:
+		_, ok := o.converter(t.Elem())
+		return objectKind, ok && t.Key().Kind() == reflect.String
+	case reflect.Struct:
+		return objectKind, true
+	default:
+		return primitiveKind, false
+	}
+}
+
+// bindStyled sets value to the values of prm decoded by the style s
+func (p extractor) bindStyled(value reflect.Value, prm param, s paramStyle, src Source) (*BoundParam, error) {
+	kind, ok := p.opts.kind(prm.field.Type)
+	if !ok {
+		return nil, ErrorUnSupportedParamType{prm.fieldError(
+			fmt.Errorf(`error unmarshalling %v into "%v", unsupported param type`, prm.field.Type, prm.key))}
+	}
+	if s.name == styleDeepObject {
+		if kind != objectKind {
+			return nil, ErrorUnSupportedParamType{prm.fieldError(
+				fmt.Errorf(`style "%v" requires a struct or map field`, s.name))}
+		}
+		return p.bindBrackets(value, prm, src)
+	}
+	if s.name == styleForm && s.explode && kind == objectKind {
+		return p.bindExploded(value, prm, src)
+	}
+
+	raw, prm, ok, err := lookup(src, prm)
+	if err != nil || !ok {
+		return nil, err
+	}
+	values, err := s.decode(prm, raw, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	if kind == objectKind {
+		root := &bracketNode{key: prm.key}
+		for i := 0; i < len(values); i += 2 {
+			child := root.child(values[i])
+			child.values = append(child.values, values[i+1])
+		}
+		prm.path = prm.field.Name
+		err = p.bindNode(value, prm.field.Type, root, prm)
+	} else {
+		err = p.set(value, prm, values)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &BoundParam{Field: prm.field.Name, Key: prm.key, In: prm.in, Alias: prm.key != prm.keys[0]}, nil
+}
+
+// bindExploded binds objects of the exploded form style, which are serialized as
+// a key for every property, e.g. R=100&G=200&B=150. Map fields capture every key
+func (p extractor) bindExploded(value reflect.Value, prm param, src Source) (*BoundParam, error) {
+	root := &bracketNode{key: prm.key, children: map[string]*bracketNode{}}
+	for _, key := range src.Keys() {
+		if prm.field.Type.Kind() == reflect.Struct && !p.property(prm.field.Type, key) {
+			continue
+		}
+		values, _ := src.Lookup(key)
+		root.children[key] = &bracketNode{key: key, values: values}
+	}
+	if len(root.children) == 0 {
+		return nil, nil
+	}
+
+	prm.path = prm.field.Name
+	err := p.bindNode(value, prm.field.Type, root, prm)
+	if err != nil {
+		return nil, err
+	}
+	return &BoundParam{Field: prm.field.Name, Key: prm.key, In: prm.in}, nil
+}
+
+// property reports whether key is a key of a field of the struct type t
+func (p extractor) property(t reflect.Type, key string) bool {
+	for i := 0; i < t.NumField(); i++ {
+		tag, ok := p.opts.fieldTag(t.Field(i))
+		if !ok {
+			continue
+		}
+		for _, k := range tag.keys() {
+			if p.sameKey(key, k) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// decode splits raw values serialized in the style s into values of a primitive or an array,
+// or into alternating names and values of object properties
+func (s paramStyle) decode(prm param, raw []string, kind valueKind) ([]string, error) {
+	var values []string
+	for _, str := range raw {
+		decoded, err := s.decodeValue(prm.key, str, kind)
+		if err != nil {
+			return nil, ErrorUnmarshalType{prm.fieldError(
+				fmt.Errorf(`error unmarshalling [%v] of style %v due to %v`, str, s.name, err))}
+		}
+		if (prm.in == InHeader || prm.in == InEnv) && kind != primitiveKind {
+			for i := range decoded {
+				decoded[i] = strings.TrimSpace(decoded[i])
+			}
+		}
+		values = append(values, decoded...)
+	}
+	return values, nil
+}
+
+func (s paramStyle) decodeValue(key, str string, kind valueKind) ([]string, error) {
+	switch s.name {
+	case styleLabel:
+		if !strings.HasPrefix(str, `.`) {
+			return nil, errors.New(`value should start with "."`)
+		}
+		str = str[1:]
+		separator := `.`
+		if !s.explode && strings.Contains(str, `,`) {
+			separator = `,`
+		}
+		return split(str, separator, kind, s.explode && kind == objectKind)
+	case styleMatrix:
+		return s.decodeMatrix(key, str, kind)
+	case styleSpaceDelimited:
+		return split(str, ` `, kind, false)
+	case stylePipeDelimited:
+		return split(str, `|`, kind, false)
+	case styleForm:
+		if s.explode {
+			return []string{str}, nil
+		}
+		return split(str, `,`, kind, false)
+	default:
+		return split(str, `,`, kind, s.explode && kind == objectKind)
+	}
+}
+
+// decodeMatrix decodes values of the matrix style, e.g. ;color=blue,black and ;color=blue;color=black
+func (s paramStyle) decodeMatrix(key, str string, kind valueKind) ([]string, error) {
+	if kind == objectKind && s.explode {
+		if !strings.HasPrefix(str, `;`) {
+			return nil, errors.New(`value should start with ";"`)
+		}
+		return split(str[1:], `;`, kind, true)
+	}
+
+	prefix := `;` + key
+	if !strings.HasPrefix(str, prefix) {
+		return nil, fmt.Errorf(`value should start with "%v"`, prefix)
+	}
+	if kind == arrayKind && s.explode {
+		var values []string
+		for _, part := range strings.Split(str[1:], `;`) {
+			name, value, ok := strings.Cut(part, `=`)
+			if !ok || name != key {
+				return nil, fmt.Errorf(`value "%v" should be of the form %v=value`, part, key)
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+
+	str = str[len(prefix):]
+	if str == `` {
+		return split(str, `,`, kind, false)
+	}
+	if str[0] != '=' {
+		return nil, fmt.Errorf(`value should start with "%v="`, prefix)
+	}
+	return split(str[1:], `,`, kind, false)
+}
+
+// split splits str into values of kind. Properties of objects are either separated into names
+// and values, e.g. R,100,G,200, or exploded as name=value pairs, e.g. R=100,G=200
+func split(str, separator string, kind valueKind, exploded bool) ([]string, error) {
+	if kind == primitiveKind {
+		return []string{str}, nil
+	}
+	if str == `` {
+		return nil, nil
+	}
+
+	parts := strings.Split(str, separator)
+	if kind == arrayKind {
+		return parts, nil
+	}
+	if !exploded {
+		if len(parts)%2 != 0 {
+			return nil, errors.New(`object should have a value for every property`)
+		}
+		return parts, nil
+	}
+
+	pairs := make([]string, 0, 2*len(parts))
+	for _, part := range parts {
+		name, value, ok := strings.Cut(part, `=`)
+		if !ok {
+			return nil, fmt.Errorf(`property "%v" should be of the form name=value`, part)
+		}
+		pairs = append(pairs, name, value)
+	}
+	return pairs, nil
+}
+
+// encode joins values of a primitive or an array, or alternating names and values of object
+// properties, into raw values serialized in the style s. It is the inverse of decode
+func (s paramStyle) encode(key string, values []string, kind valueKind) []string {
+	if kind != primitiveKind && len(values) == 0 {
+		return nil
+	}
+	exploded := s.explode && kind == objectKind
+	switch s.name {
+	case styleLabel:
+		return []string{`.` + join(values, `.`, exploded)}
+	case styleMatrix:
+		return s.encodeMatrix(key, values, kind)
+	case styleSpaceDelimited:
+		return []string{join(values, ` `, false)}
+	case stylePipeDelimited:
+		return []string{join(values, `|`, false)}
+	case styleForm:
+		if s.explode {
+			return values
+		}
+		return []string{join(values, `,`, false)}
+	default:
+		return []string{join(values, `,`, exploded)}
+	}
+}
+
+// encodeMatrix encodes values in the matrix style, e.g. ;color=blue,black and ;color=blue;color=black
+func (s paramStyle) encodeMatrix(key string, values []string, kind valueKind) []string {
+	switch {
+	case kind == objectKind && s.explode:
+		return []string{`;` + join(values, `;`, true)}
+	case kind == arrayKind && s.explode:
+		return []string{`;` + key + `=` + strings.Join(values, `;`+key+`=`)}
+	case kind == primitiveKind && values[0] == ``:
+		return []string{`;` + key}
+	default:
+		return []string{`;` + key + `=` + join(values, `,`, false)}
+	}
+}
+
+// join joins values by separator. Alternating names and values of exploded objects are
+// joined as name=value pairs, e.g. R=100,G=200
+func join(values []string, separator string, exploded bool) string {
+	if !exploded {
+		return strings.Join(values, separator)
+	}
+	pairs := make([]string, 0, len(values)/2)
+	for i := 0; i < len(values); i += 2 {
+		pairs = append(pairs, values[i]+`=`+values[i+1])
+	}
+	return strings.Join(pairs, separator)
+}
@@ -0,0 +1,225 @@
+package paramex
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type styleColor struct {
+	R int `param:"R"`
+	G int `param:"G"`
+	B int `param:"B"`
+}
+
+var (
+	stylePrimitive = `blue`
+	styleArray     = []string{`blue`, `black`, `brown`}
+	styleObject    = styleColor{R: 100, G: 200, B: 150}
+	styleMap       = map[string]int{`R`: 100, `G`: 200, `B`: 150}
+)
+
+// styledRequest returns a request sending raw as the color param in the in location
+func styledRequest(in In, raw string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, `https://nipuna.lk/colors`, nil)
+	switch in {
+	case InQuery:
+		req.URL.RawQuery = raw
+	case InPath:
+		req.SetPathValue(`color`, raw)
+	case InHeader:
+		req.Header.Set(`color`, raw)
+	case InCookie:
+		req.Header.Set(`Cookie`, raw)
+	}
+	return req
+}
+
+// styledStruct returns a reference to a struct having a Color field of the type
+// of expected, which is tagged by tag
+func styledStruct(expected interface{}, tag string) interface{} {
+	t := reflect.StructOf([]reflect.StructField{{
+		Name: `Color`,
+		Type: reflect.TypeOf(expected),
+		Tag:  reflect.StructTag(`param:"` + tag + `"`),
+	}})
+	return reflect.New(t).Interface()
+}
+
+// Test_StyleConformance decodes the serialization examples of the OpenAPI 3 specification
+func Test_StyleConformance(t *testing.T) {
+	tests := []struct {
+		tag      string
+		in       In
+		raw      string
+		expected interface{}
+	}{
+		{`color,style=matrix`, InPath, `;color`, ``},
+		{`color,style=matrix`, InPath, `;color=blue`, stylePrimitive},
+		{`color,style=matrix`, InPath, `;color=blue,black,brown`, styleArray},
+		{`color,style=matrix`, InPath, `;color=R,100,G,200,B,150`, styleObject},
+		{`color,style=matrix,explode`, InPath, `;color=blue`, stylePrimitive},
+		{`color,style=matrix,explode=true`, InPath, `;color=blue;color=black;color=brown`, styleArray},
+		{`color,style=matrix,explode=true`, InPath, `;R=100;G=200;B=150`, styleObject},
+		{`color,style=matrix,explode=true`, InPath, `;R=100;G=200;B=150`, styleMap},
+		{`color,style=label`, InPath, `.`, ``},
+		{`color,style=label`, InPath, `.blue`, stylePrimitive},
+		{`color,style=label`, InPath, `.blue.black.brown`, styleArray},
+		{`color,style=label`, InPath, `.blue,black,brown`, styleArray},
+		{`color,style=label`, InPath, `.R.100.G.200.B.150`, styleObject},
+		{`color,style=label,explode`, InPath, `.blue`, stylePrimitive},
+		{`color,style=label,explode`, InPath, `.blue.black.brown`, styleArray},
+		{`color,style=label,explode`, InPath, `.R=100.G=200.B=150`, styleObject},
+		{`color,style=form,explode=false`, InQuery, `color=`, ``},
+		{`color,style=form,explode=false`, InQuery, `color=blue`, stylePrimitive},
+		{`color,style=form,explode=false`, InQuery, `color=blue,black,brown`, styleArray},
+		{`color,style=form,explode=false`, InQuery, `color=R,100,G,200,B,150`, styleObject},
+		{`color,style=form,explode=false`, InQuery, `color=R,100,G,200,B,150`, styleMap},
+		{`color,style=form`, InQuery, `color=`, ``},
+		{`color,style=form`, InQuery, `color=blue`, stylePrimitive},
+		{`color,style=form`, InQuery, `color=blue&color=black&color=brown`, styleArray},
+		{`color,style=form`, InQuery, `R=100&G=200&B=150`, styleObject},
+		{`color,style=form`, InQuery, `R=100&G=200&B=150`, styleMap},
+		{`color`, InPath, `blue`, stylePrimitive},
+		{`color,style=simple`, InPath, `blue,black,brown`, styleArray},
+		{`color,style=simple`, InPath, `R,100,G,200,B,150`, styleObject},
+		{`color,style=simple,explode`, InPath, `blue`, stylePrimitive},
+		{`color,style=simple,explode`, InPath, `blue,black,brown`, styleArray},
+		{`color,style=simple,explode`, InPath, `R=100,G=200,B=150`, styleObject},
+		{`color`, InHeader, `blue`, stylePrimitive},
+		{`color`, InHeader, `blue,black,brown`, styleArray},
+		{`color`, InHeader, `blue, black, brown`, styleArray},
+		{`color,style=simple`, InHeader, `R,100,G,200,B,150`, styleObject},
+		{`color,style=simple,explode`, InHeader, `R=100,G=200,B=150`, styleObject},
+		{`color,style=spaceDelimited`, InQuery, `color=blue%20black%20brown`, styleArray},
+		{`color,style=spaceDelimited`, InQuery, `color=R%20100%20G%20200%20B%20150`, styleObject},
+		{`color,style=pipeDelimited`, InQuery, `color=blue|black|brown`, styleArray},
+		{`color,style=pipeDelimited`, InQuery, `color=R|100|G|200|B|150`, styleObject},
+		{`color,style=deepObject`, InQuery, `color[R]=100&color[G]=200&color[B]=150`, styleObject},
+		{`color,style=deepObject`, InQuery, `color[R]=100&color[G]=200&color[B]=150`, styleMap},
+		{`color`, InCookie, `color=blue`, stylePrimitive},
+		{`color,style=form,explode=false`, InCookie, `color=blue,black,brown`, styleArray},
+	}
+
+	extractor := NewParamExtractor()
+	for _, test := range tests {
+		name := string(test.in) + ` ` + test.tag + ` ` + test.raw + ` into ` + reflect.TypeOf(test.expected).String()
+		t.Run(name, func(t *testing.T) {
+			v := styledStruct(test.expected, test.tag)
+			result, err := extractor.Bind(v, styledRequest(test.in, test.raw), test.in)
+			if err != nil {
+				t.Fatalf(`unexpected error %v`, err)
+			}
+			if len(result.Params) != 1 {
+				t.Fatalf(`expected a bound param, but received %v`, result.Params)
+			}
+			received := reflect.ValueOf(v).Elem().Field(0).Interface()
+			if !reflect.DeepEqual(received, test.expected) {
+				t.Errorf(`expected %#v, but received %#v`, test.expected, received)
+			}
+		})
+	}
+}
+
+func TestExtractor_Styles(t *testing.T) {
+	extractor := NewParamExtractor()
+
+	t.Run(`test malformed values`, func(t *testing.T) {
+		tests := []struct {
+			tag      string
+			in       In
+			raw      string
+			expected interface{}
+		}{
+			{`color,style=matrix`, InPath, `color=blue`, ``},
+			{`color,style=matrix`, InPath, `;colour=blue`, ``},
+			{`color,style=matrix,explode`, InPath, `;color=blue;colour=black`, styleArray},
+			{`color,style=label`, InPath, `blue`, ``},
+			{`color,style=simple`, InPath, `R,100,G`, styleObject},
+			{`color,style=simple,explode`, InPath, `R=100,G`, styleObject},
+			{`color,style=pipeDelimited`, InQuery, `color=blue|black`, []int{}},
+		}
+		for _, test := range tests {
+			v := styledStruct(test.expected, test.tag)
+			_, err := extractor.Bind(v, styledRequest(test.in, test.raw), test.in)
+			var unmarshalErr ErrorUnmarshalType
+			if !errors.As(err, &unmarshalErr) {
+				t.Errorf(`%v: expected "ErrorUnmarshalType", but received %v`, test.raw, err)
+			}
+		}
+	})
+
+	t.Run(`test unsupported styles`, func(t *testing.T) {
+		tests := []struct {
+			tag string
+			in  In
+		}{
+			{`color,style=matrix`, InQuery},
+			{`color,style=form`, InPath},
+			{`color,style=label`, InHeader},
+			{`color,style=deepObject`, InCookie},
+			{`color,style=form,explode=yes`, InQuery},
+		}
+		for _, test := range tests {
+			v := styledStruct(``, test.tag)
+			_, err := extractor.Bind(v, styledRequest(test.in, `color=blue`), test.in)
+			if _, ok := err.(ErrorUnSupportedParamType); !ok {
+				t.Errorf(`%v in %v: expected "ErrorUnSupportedParamType", but received %v`, test.tag, test.in, err)
+			}
+		}
+	})
+
+	t.Run(`test path values`, func(t *testing.T) {
+		obj := struct {
+			ID    int      `param:"id"`
+			Color []string `param:"color,style=label"`
+		}{}
+		req := httptest.NewRequest(http.MethodGet, `https://nipuna.lk/users/5/.red.blue`, nil)
+		values := map[string]string{`id`: `5`, `color`: `.red.blue`}
+		extractor := NewParamExtractor(WithPathValues(func(req *http.Request, name string) string {
+			return values[name]
+		}))
+		err := extractor.ExtractPath(&obj, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if obj.ID != 5 || !reflect.DeepEqual(obj.Color, []string{`red`, `blue`}) {
+			t.Errorf(`unexpected path params %+v`, obj)
+		}
+	})
+
+	t.Run(`test request path values`, func(t *testing.T) {
+		obj := struct {
+			ID int `param:"id"`
+		}{}
+		mux := http.NewServeMux()
+		mux.HandleFunc(`/users/{id}`, func(w http.ResponseWriter, req *http.Request) {
+			err := extractor.ExtractPath(&obj, req)
+			if err != nil {
+				t.Error(err)
+			}
+		})
+		mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, `/users/12`, nil))
+		if obj.ID != 12 {
+			t.Errorf(`expected id 12, but received %v`, obj.ID)
+		}
+	})
+
+	t.Run(`test cookies`, func(t *testing.T) {
+		obj := struct {
+			Session string   `param:"session"`
+			Seen    []string `param:"seen"`
+		}{}
+		req := httptest.NewRequest(http.MethodGet, `https://nipuna.lk`, nil)
+		req.Header.Set(`Cookie`, `session=abc; seen=a; seen=b; tracking=1`)
+		err := NewParamExtractor(WithStrict()).ExtractCookies(&obj, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if obj.Session != `abc` || !reflect.DeepEqual(obj.Seen, []string{`a`, `b`}) {
+			t.Errorf(`unexpected cookies %+v`, obj)
+		}
+	})
+}
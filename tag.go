@@ -0,0 +1,181 @@
+package paramex
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// paramTag is a parsed field tag of the form `param:"name,option,option=value"`
+type paramTag struct {
+	name    string
+	options map[string]string
+}
+
+// parseTag parses a tag. Commas of option values are escaped by a backslash, e.g. `param:"code,pattern=^\\w{1\\,3}$"`
+func parseTag(tag string) paramTag {
+	parts := splitEscaped(tag, ',')
+	t := paramTag{name: parts[0]}
+	for _, part := range parts[1:] {
+		if part == `` {
+			continue
+		}
+		if t.options == nil {
+			t.options = map[string]string{}
+		}
+		key, value := part, ``
+		if i := strings.Index(part, `=`); i >= 0 {
+			key, value = part[:i], part[i+1:]
+		}
+		t.options[key] = value
+	}
+	return t
+}
+
+// splitEscaped splits s by sep, except separators escaped by a backslash, which are unescaped
+func splitEscaped(s string, sep byte) []string {
+	var parts []string
+	var part strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && s[i+1] == sep:
+			part.WriteByte(sep)
+			i++
+		case s[i] == sep:
+			parts = append(parts, part.String())
+			part.Reset()
+		default:
+			part.WriteByte(s[i])
+		}
+	}
+	return append(parts, part.String())
+}
+
+// keys returns the key and the alias keys of the tag name, e.g. `param:"user_id|userId"`
+func (t paramTag) keys() []string {
+	return strings.Split(t.name, `|`)
+}
+
+// prefix reports whether the tag captures every key starting with its keys,
+// e.g. `param:"meta.*"` or `param:"X-Meta-,prefix"`
+func (t paramTag) prefix() bool {
+	_, ok := t.option(`prefix`)
+	return ok || strings.HasSuffix(t.name, `*`)
+}
+
+// located reports whether the field is bound to params of the in location, which is set by the in
+// option, e.g. `param:"id,in=path"`. Fields without the option are bound to the fallback location,
+// or to every location when fallback is empty
+func (t paramTag) located(in, fallback In) bool {
+	location, ok := t.option(`in`)
+	if !ok {
+		return fallback == `` || fallback == in
+	}
+	return In(location) == in
+}
+
+// option returns the value of the option and reports whether the option is set
+func (t paramTag) option(name string) (string, bool) {
+	value, ok := t.options[name]
+	return value, ok
+}
+
+// fieldTag returns the parsed tag of field and reports whether field is bound to a parameter.
+// Fields without a tag or a tag name are named by the naming strategy, when it is set.
+// Body fields are not bound to parameters
+func (o options) fieldTag(field reflect.StructField) (paramTag, bool) {
+	if field.PkgPath != `` {
+		return paramTag{}, false
+	}
+
+	raw, ok := field.Tag.Lookup(o.tagName)
+	if !ok && o.naming == nil {
+		return paramTag{}, false
+	}
+	tag := parseTag(raw)
+	if tag.name == `-` || tag.body(field) {
+		return tag, false
+	}
+	if tag.name == `` && o.naming != nil {
+		tag.name = o.naming(field.Name)
+	}
+	return tag, true
+}
+
+// body reports whether field is bound to the request body, which is a field having the body option,
+// e.g. `param:",body"`, or a field named Body without a tag name
+func (t paramTag) body(field reflect.StructField) bool {
+	_, ok := t.option(`body`)
+	return ok || field.Name == `Body` && t.name == ``
+}
+
+// NamingStrategy derives the parameter key of a field from the Go field name
+type NamingStrategy func(field string) string
+
+// SnakeCase names UserID field as user_id
+func SnakeCase(field string) string {
+	return strings.ToLower(strings.Join(splitWords(field), `_`))
+}
+
+// KebabCase names UserID field as user-id
+func KebabCase(field string) string {
+	return strings.ToLower(strings.Join(splitWords(field), `-`))
+}
+
+// CamelCase names UserID field as userId
+func CamelCase(field string) string {
+	words := splitWords(field)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+		if i > 0 {
+			words[i] = capitalize(words[i])
+		}
+	}
+	return strings.Join(words, ``)
+}
+
+// HeaderCase names XRequestID field as X-Request-Id
+func HeaderCase(field string) string {
+	words := splitWords(field)
+	for i, word := range words {
+		words[i] = capitalize(strings.ToLower(word))
+	}
+	return strings.Join(words, `-`)
+}
+
+func capitalize(word string) string {
+	runes := []rune(word)
+	if len(runes) == 0 {
+		return word
+	}
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// splitWords splits a Go identifier into words, keeping acronyms and
+// trailing digits together, e.g. HTTPServer2ID is split as HTTP, Server2, ID
+func splitWords(name string) []string {
+	runes := []rune(name)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev, curr := runes[i-1], runes[i]
+		switch {
+		case curr == '_':
+			if start < i {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+		case unicode.IsUpper(curr) && (unicode.IsLower(prev) || unicode.IsDigit(prev)),
+			unicode.IsUpper(curr) && unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+			if start < i {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
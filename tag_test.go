@@ -0,0 +1,51 @@
+package paramex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseTag(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected paramTag
+	}{
+		{``, paramTag{}},
+		{`name`, paramTag{name: `name`}},
+		{`-`, paramTag{name: `-`}},
+		{`role,multi=error`, paramTag{name: `role`, options: map[string]string{`multi`: `error`}}},
+		{`,flag,,option=with space`, paramTag{options: map[string]string{`flag`: ``, `option`: `with space`}}},
+	}
+	for _, test := range tests {
+		received := parseTag(test.tag)
+		if !reflect.DeepEqual(received, test.expected) {
+			t.Errorf(`expected [%+v] for "%s", but received [%+v]`, test.expected, test.tag, received)
+		}
+	}
+}
+
+func Test_NamingStrategies(t *testing.T) {
+	tests := []struct {
+		field, snake, kebab, camel, header string
+	}{
+		{`Name`, `name`, `name`, `name`, `Name`},
+		{`UserID`, `user_id`, `user-id`, `userId`, `User-Id`},
+		{`XRequestID`, `x_request_id`, `x-request-id`, `xRequestId`, `X-Request-Id`},
+		{`HTTPServer2ID`, `http_server2_id`, `http-server2-id`, `httpServer2Id`, `Http-Server2-Id`},
+		{`Other_Names`, `other_names`, `other-names`, `otherNames`, `Other-Names`},
+	}
+	for _, test := range tests {
+		if received := SnakeCase(test.field); received != test.snake {
+			t.Errorf(`expected [%s], but received [%s]`, test.snake, received)
+		}
+		if received := KebabCase(test.field); received != test.kebab {
+			t.Errorf(`expected [%s], but received [%s]`, test.kebab, received)
+		}
+		if received := CamelCase(test.field); received != test.camel {
+			t.Errorf(`expected [%s], but received [%s]`, test.camel, received)
+		}
+		if received := HeaderCase(test.field); received != test.header {
+			t.Errorf(`expected [%s], but received [%s]`, test.header, received)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package paramex
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// constraints of a field, which are set by the required, default, enum, min, max, pattern,
+// minLength, maxLength, minItems and maxItems tag options, e.g. `param:"sort,default=asc,enum=asc|desc"`,
+// `param:"limit,required,min=1,max=100"` or `param:"code,pattern=^[A-Z]{3}$,maxLength=3"`
+type constraints struct {
+	required   bool
+	hasDefault bool
+	// defaultValue is the value of absent parameters. Values of slice defaults and enums are
+	// separated by |, which is escaped by a backslash in values
+	defaultValue string
+	enum         []string
+	min          *float64
+	max          *float64
+	pattern      *regexp.Regexp
+	minLength    *int
+	maxLength    *int
+	minItems     *int
+	maxItems     *int
+}
+
+// patterns caches compiled patterns, since tags are parsed for every extraction
+var patterns sync.Map
+
+func (t paramTag) constraints() (constraints, error) {
+	var c constraints
+	_, c.required = t.option(`required`)
+	c.defaultValue, c.hasDefault = t.option(`default`)
+	if enum, ok := t.option(`enum`); ok {
+		c.enum = splitEscaped(enum, '|')
+	}
+
+	var err error
+	c.min, err = t.number(`min`)
+	if err != nil {
+		return c, err
+	}
+	c.max, err = t.number(`max`)
+	if err != nil {
+		return c, err
+	}
+	lengths := []struct {
+		name  string
+		value **int
+	}{{`minLength`, &c.minLength}, {`maxLength`, &c.maxLength}, {`minItems`, &c.minItems}, {`maxItems`, &c.maxItems}}
+	for _, length := range lengths {
+		*length.value, err = t.length(length.name)
+		if err != nil {
+			return c, err
+		}
+	}
+
+	if pattern, ok := t.option(`pattern`); ok {
+		c.pattern, err = compilePattern(pattern)
+	}
+	return c, err
+}
+
+// length returns the non negative integer value of the option, or nil when the option is not set
+func (t paramTag) length(name string) (*int, error) {
+	str, ok := t.option(name)
+	if !ok {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(str)
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf(`invalid %v "%v", required a non negative integer`, name, str)
+	}
+	return &n, nil
+}
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf(`invalid pattern "%v" due to %v`, pattern, err)
+	}
+	patterns.Store(pattern, re)
+	return re, nil
+}
+
+// number returns the numeric value of the option, or nil when the option is not set
+func (t paramTag) number(name string) (*float64, error) {
+	str, ok := t.option(name)
+	if !ok {
+		return nil, nil
+	}
+	n, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return nil, fmt.Errorf(`invalid %v "%v", required a number`, name, str)
+	}
+	return &n, nil
+}
+
+// absent sets value of an absent parameter to the default value of prm,
+// or fails with ErrorRequiredParam when prm is required
+func (p extractor) absent(value reflect.Value, prm param) error {
+	c, err := prm.tag.constraints()
+	if err != nil {
+		return ErrorUnSupportedParamType{prm.fieldError(err)}
+	}
+
+	switch {
+	case c.hasDefault:
+		values := []string{c.defaultValue}
+		if prm.field.Type.Kind() == reflect.Slice {
+			values = splitEscaped(c.defaultValue, '|')
+		}
+		err = p.set(value, prm, values)
+		if err != nil {
+			return ErrorUnSupportedParamType{prm.fieldError(
+				fmt.Errorf(`invalid default value "%v" due to %v`, c.defaultValue, err))}
+		}
+		return nil
+	case c.required:
+		return ErrorRequiredParam{prm.fieldError(fmt.Errorf(`required %v param "%v" is missing`, prm.in, prm.key))}
+	default:
+		return nil
+	}
+}
+
+// validate checks the bound value of prm against the constraints of the field tag.
+// Every element of slices is checked, except the minItems and maxItems constraints
+func (p extractor) validate(value reflect.Value, prm param) error {
+	c, err := prm.tag.constraints()
+	if err != nil {
+		return ErrorUnSupportedParamType{prm.fieldError(err)}
+	}
+	if _, ok := p.opts.converter(value.Type()); !ok && value.Kind() == reflect.Slice {
+		switch {
+		case c.minItems != nil && value.Len() < *c.minItems:
+			return ErrorInvalidValue{prm.fieldError(fmt.Errorf(`param "%v" has %d values, less than the minimum %d`,
+				prm.key, value.Len(), *c.minItems))}
+		case c.maxItems != nil && value.Len() > *c.maxItems:
+			return ErrorInvalidValue{prm.fieldError(fmt.Errorf(`param "%v" has %d values, greater than the maximum %d`,
+				prm.key, value.Len(), *c.maxItems))}
+		}
+		for i := 0; i < value.Len(); i++ {
+			err := p.check(value.Index(i), prm, c)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return p.check(value, prm, c)
+}
+
+func (p extractor) check(value reflect.Value, prm param, c constraints) error {
+	if conv, ok := p.opts.converter(value.Type()); ok && len(c.enum) > 0 {
+		allowed := false
+		for _, str := range c.enum {
+			option, err := conv.convertTo(prm, value.Type(), str)
+			if err != nil {
+				return ErrorUnSupportedParamType{prm.fieldError(fmt.Errorf(`invalid enum value "%v"`, str))}
+			}
+			allowed = allowed || reflect.DeepEqual(value.Interface(), option.Interface())
+		}
+		if !allowed {
+			return ErrorInvalidValue{prm.fieldError(fmt.Errorf(`value [%v] of param "%v" is not one of [%v]`,
+				value.Interface(), prm.key, strings.Join(c.enum, `, `)))}
+		}
+	}
+
+	if value.Kind() == reflect.String {
+		err := checkString(value.String(), prm, c)
+		if err != nil {
+			return err
+		}
+	}
+
+	n, ok := number(value)
+	switch {
+	case !ok:
+		return nil
+	case c.min != nil && n < *c.min:
+		return ErrorInvalidValue{prm.fieldError(fmt.Errorf(`value [%v] of param "%v" is less than the minimum %v`,
+			value.Interface(), prm.key, *c.min))}
+	case c.max != nil && n > *c.max:
+		return ErrorInvalidValue{prm.fieldError(fmt.Errorf(`value [%v] of param "%v" is greater than the maximum %v`,
+			value.Interface(), prm.key, *c.max))}
+	default:
+		return nil
+	}
+}
+
+// number returns the value of numeric kinds as a float64
+func number(value reflect.Value) (float64, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// checkString checks str against the pattern, minLength and maxLength constraints. Lengths are
+// counted in unicode code points, same as JSON Schema
+func checkString(str string, prm param, c constraints) error {
+	length := utf8.RuneCountInString(str)
+	switch {
+	case c.minLength != nil && length < *c.minLength:
+		return ErrorInvalidValue{prm.fieldError(fmt.Errorf(`value [%v] of param "%v" is shorter than the minimum length %d`,
+			str, prm.key, *c.minLength))}
+	case c.maxLength != nil && length > *c.maxLength:
+		return ErrorInvalidValue{prm.fieldError(fmt.Errorf(`value [%v] of param "%v" is longer than the maximum length %d`,
+			str, prm.key, *c.maxLength))}
+	case c.pattern != nil && !c.pattern.MatchString(str):
+		return ErrorInvalidValue{prm.fieldError(fmt.Errorf(`value [%v] of param "%v" does not match the pattern %v`,
+			str, prm.key, c.pattern))}
+	default:
+		return nil
+	}
+}